@@ -0,0 +1,140 @@
+package anytime
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	gp "github.com/ijt/goparsify"
+)
+
+// Portion is a part of a period, running from the fraction From of the way
+// through it to the fraction To.
+type Portion struct {
+	From, To float64
+}
+
+// PeriodPortions are the parts of a period meant by "early", "mid" and
+// "late", as in "early march", "mid-2023" and "late last year".
+type PeriodPortions struct {
+	Early, Mid, Late Portion
+}
+
+// thirds are the default period portions, which split periods into thirds so
+// that "early march" is March 1 to 10, "mid-march" is March 11 to 21 and
+// "late march" is March 22 to 31.
+var thirds = PeriodPortions{
+	Early: Portion{0, 1.0 / 3},
+	Mid:   Portion{1.0 / 3, 2.0 / 3},
+	Late:  Portion{2.0 / 3, 1},
+}
+
+// defaultEndOfDayHour is the hour meant by "EOD" and "COB" by default, as in
+// 5pm.
+const defaultEndOfDayHour = 17
+
+// WithPeriodPortions sets the option to take "early", "mid" and "late", as
+// in "early march", to mean the portions p of a period rather than its
+// thirds.
+func WithPeriodPortions(p PeriodPortions) func(o *opts) {
+	return func(o *opts) {
+		o.portions = p
+	}
+}
+
+// WithEndOfDayHour sets the option to take "EOD" and "COB" to mean the hour h
+// rather than 5pm.
+func WithEndOfDayHour(h int) func(o *opts) {
+	return func(o *opts) {
+		o.endOfDayHour = h
+	}
+}
+
+// periodPart parses the words that pick out part of a period, as in "end of"
+// or "mid-", resulting in a func giving that part of a period. The start and
+// end of a period are its first and last days, and early, mid and late are
+// the given portions of it.
+func periodPart(portions PeriodPortions) gp.Parser {
+	rx := `(?i)((the\s+)?(start|beginning|end|middle)\s+of|(early|late)(\s+in)?|mid)\b-?`
+	return gp.NamedRegex("part of a period", rx).Map(func(n *gp.Result) {
+		words := strings.Fields(strings.ToLower(strings.TrimSuffix(n.Token, "-")))
+		if words[0] == "the" {
+			words = words[1:]
+		}
+		switch words[0] {
+		case "start", "beginning":
+			n.Result = func(r Range) Range { return truncateDay(r.Time) }
+		case "end":
+			n.Result = func(r Range) Range { return truncateDay(r.End()) }
+		case "early":
+			n.Result = portions.Early.of
+		case "late":
+			n.Result = portions.Late.of
+		default:
+			n.Result = portions.Mid.of
+		}
+	})
+}
+
+// of returns the days of r that make up the portion p of it, with at least
+// one day.
+func (p Portion) of(r Range) Range {
+	days := 0
+	for d := r.Time; !d.After(r.End()); d = d.AddDate(0, 0, 1) {
+		days++
+	}
+	from := int(math.Round(p.From * float64(days)))
+	to := int(math.Round(p.To * float64(days)))
+	if from < 0 {
+		from = 0
+	}
+	if from > days-1 {
+		from = days - 1
+	}
+	if to <= from {
+		to = from + 1
+	}
+	if to > days {
+		to = days
+	}
+	s := r.Time.AddDate(0, 0, from)
+	e := r.Time.AddDate(0, 0, to).Add(-time.Second)
+	return Range{s, e.Sub(s), Day}
+}
+
+// endOfDayWords parses the words for the end of a day, as in "EOD", "COB" or
+// "end of the day".
+var endOfDayWords = gp.NamedRegex("end of day", `(?i)(eod|cob|close\s+of\s+business|end\s+of\s+(the\s+)?day)\b`)
+
+// endOfDay returns a parser of the end of a day, as in "EOD friday" or "COB
+// tomorrow", taken to be the hour h on that day. The day is parsed by day and
+// defaults to the day of ref if it is left out.
+func endOfDay(ref time.Time, h int, day gp.Parser) gp.Parser {
+	return gp.Seq(endOfDayWords, gp.Maybe(singleDay(day))).Map(func(n *gp.Result) {
+		d := truncateDay(ref)
+		if c1 := n.Child[1].Result; c1 != nil {
+			d = c1.(Range)
+		}
+		n.Result = Range{
+			time.Date(d.Year(), d.Month(), d.Day(), h, 0, 0, 0, d.Location()),
+			time.Hour - time.Second,
+			Hour,
+		}
+	})
+}
+
+// singleDay returns a parser that only accepts the ranges from p that are
+// single days.
+func singleDay(p gp.Parser) gp.Parser {
+	return func(ps *gp.State, node *gp.Result) {
+		start := ps.Pos
+		p(ps, node)
+		if ps.Errored() {
+			return
+		}
+		if r, ok := node.Result.(Range); !ok || r.Granularity != Day || r.End().After(r.AddDate(0, 0, 1)) {
+			ps.Pos = start
+			ps.ErrorHere("a single day")
+		}
+	}
+}
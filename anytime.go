@@ -15,6 +15,59 @@ import (
 type Range struct {
 	time.Time
 	Duration time.Duration
+
+	// Granularity is the unit of time in which the range was expressed, for
+	// example Month for "march 2023" or Day for "from march 1 to april 1".
+	Granularity Granularity
+}
+
+// Granularity is a unit of time in which a Range can be expressed.
+type Granularity int
+
+// Granularities, from finest to coarsest.
+const (
+	Second Granularity = iota
+	Minute
+	Hour
+	Day
+	Week
+	Month
+	Quarter
+	Half
+	Year
+)
+
+// String returns the name of the granularity.
+func (g Granularity) String() string {
+	switch g {
+	case Second:
+		return "second"
+	case Minute:
+		return "minute"
+	case Hour:
+		return "hour"
+	case Day:
+		return "day"
+	case Week:
+		return "week"
+	case Month:
+		return "month"
+	case Quarter:
+		return "quarter"
+	case Half:
+		return "half"
+	case Year:
+		return "year"
+	}
+	return "unknown"
+}
+
+// finer returns whichever of g and h is the finer granularity.
+func finer(g, h Granularity) Granularity {
+	if h < g {
+		return h
+	}
+	return g
 }
 
 func (r Range) Start() time.Time {
@@ -25,9 +78,10 @@ func (r Range) End() time.Time {
 	return r.Time.Add(r.Duration)
 }
 
-// RangeFromTimes returns a range given the start and end times.
+// RangeFromTimes returns a range given the start and end times, with Second
+// granularity.
 func RangeFromTimes(start, end time.Time) Range {
-	return Range{start, end.Sub(start)}
+	return Range{start, end.Sub(start), Second}
 }
 
 // String returns a string with the time and duration of the range.
@@ -44,6 +98,48 @@ const (
 
 type opts struct {
 	defaultDirection direction
+	zonePolicy       ZonePolicy
+	weekStart        time.Weekday
+	dateOrder        DateOrder
+	fiscalYearStart  time.Month
+	portions         PeriodPortions
+	endOfDayHour     int
+	dayParts         map[string]DayPart
+
+	// rejection, if not nil, is set to the first error, such as
+	// ErrAmbiguousDate, that a parser rejected what it was given with.
+	rejection *error
+
+	// bareNumber is how to read a number on its own, as in "12". Such
+	// numbers are only read when ParseAll asks for one of their readings.
+	bareNumber bareNumberReading
+}
+
+// noteRejection sets the option to set *rejection to the first error, such
+// as ErrAmbiguousDate or ErrNoSuchDay, that a parser rejects what it was
+// given with, so that Parse, ParseRange and ParseOpenRange can return it as
+// the reason they failed.
+func noteRejection(rejection *error) func(o *opts) {
+	return func(o *opts) {
+		o.rejection = rejection
+	}
+}
+
+// reject notes err as the reason a parser rejected what it was given, if the
+// noteRejection option asked for it and no other reason was noted first.
+func (o opts) reject(err error) {
+	if o.rejection != nil && *o.rejection == nil {
+		*o.rejection = err
+	}
+}
+
+// rejectionError returns the error that a parser rejected what it was given
+// with, if any, and otherwise err.
+func rejectionError(rejection, err error) error {
+	if rejection != nil {
+		return rejection
+	}
+	return err
 }
 
 // DefaultToFuture sets the option to default to the future in case of
@@ -58,6 +154,15 @@ func DefaultToPast(o *opts) {
 	o.defaultDirection = past
 }
 
+// WeekStartsOn sets the option to start weeks on the given day rather than
+// on Sunday, as in "this week" and "last week". Use time.Monday for ISO 8601
+// weeks.
+func WeekStartsOn(d time.Weekday) func(o *opts) {
+	return func(o *opts) {
+		o.weekStart = d
+	}
+}
+
 // ReplaceDateRangesByFunc replaces all ranges with duration over one day in the
 // given string s by running func f on each found range and the source string
 // that defines it.
@@ -206,10 +311,11 @@ func PartitionTimesByFuncs(s string, ref time.Time, ntf func(nonTimeChunk string
 // Parse parses a string assumed to contain a date, a time, or a datetime
 // in one of various formats.
 func Parse(s string, ref time.Time, opts ...func(o *opts)) (time.Time, error) {
-	p := Parser(ref, opts...)
+	var rejection error
+	p := Parser(ref, withOptions(opts, noteRejection(&rejection))...)
 	result, _, err := gp.Run(p, s, gp.UnicodeWhitespace)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("running parser: %w", err)
+		return time.Time{}, fmt.Errorf("running parser: %w", rejectionError(rejection, err))
 	}
 	t := result.(Range)
 	return t.Time, nil
@@ -219,16 +325,19 @@ func Parse(s string, ref time.Time, opts ...func(o *opts)) (time.Time, error) {
 // The result is a Range so that we have a time scale to work with, mainly
 // for parsing implicit ranges within RangeParser().
 func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
-	var o opts
+	o := opts{
+		portions:     thirds,
+		endOfDayHour: defaultEndOfDayHour,
+		dayParts:     defaultDayParts(),
+	}
 	for _, optFunc := range options {
 		optFunc(&o)
 	}
 
 	sep := gp.Maybe(gp.AnyWithName("separator", "/", "-", ","))
-	sladash := gp.AnyWithName("slash or dash", "/", "-")
 	comma := gp.Maybe(",")
 
-	now := gp.Bind(I("now"), Range{ref, time.Nanosecond})
+	now := gp.Bind(I("now"), Range{ref, time.Nanosecond, Second})
 
 	prevMo := gp.Seq(I("last"), I("month")).Map(func(n *gp.Result) {
 		n.Result = truncateMonth(ref.AddDate(0, -1, 0))
@@ -243,15 +352,15 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 	})
 
 	lastWeekParser := gp.Seq(I("last"), I("week")).Map(func(n *gp.Result) {
-		n.Result = lastWeek(ref)
+		n.Result = lastWeek(ref, o.weekStart)
 	})
 
 	thisWeekParser := gp.Seq(I("this"), I("week")).Map(func(n *gp.Result) {
-		n.Result = thisWeek(ref)
+		n.Result = thisWeek(ref, o.weekStart)
 	})
 
 	nextWeekParser := gp.Seq(I("next"), I("week")).Map(func(n *gp.Result) {
-		n.Result = nextWeek(ref)
+		n.Result = nextWeek(ref, o.weekStart)
 	})
 
 	one := gp.Bind(I("one"), 1)
@@ -286,14 +395,14 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		num := n.Child[0].Result.(int)
 		s := ref.AddDate(0, -num, 0)
 		dur := s.AddDate(0, 1, 0).Sub(s) - time.Second
-		n.Result = Range{s, dur}
+		n.Result = Range{s, dur, Month}
 	})
 
 	monthsFromNow := gp.Seq(number, months, gp.Any(gp.Seq(I("from"), I("now")), I("hence"))).Map(func(n *gp.Result) {
 		num := n.Child[0].Result.(int)
 		s := ref.AddDate(0, num, 0)
 		dur := s.AddDate(0, 1, 0).Sub(s) - time.Second
-		n.Result = Range{s, dur}
+		n.Result = Range{s, dur, Month}
 	})
 
 	shortWeekdays := []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
@@ -352,14 +461,6 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		n.Result = n.Child[0].Result
 	})
 
-	monthNum := gp.Regex(`[01]?\d\b`).Map(func(n *gp.Result) {
-		m, err := strconv.Atoi(n.Token)
-		if err != nil {
-			panic(fmt.Sprintf("parsing month number: %v", err))
-		}
-		n.Result = time.Month(m)
-	})
-
 	month := gp.AnyWithName("month", longMonth, shortMonthMaybeDot)
 
 	lastSpecificMonth := gp.Seq(I("last"), month).Map(func(n *gp.Result) {
@@ -419,7 +520,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		n.Result = s
 	})
 
-	amPM := gp.AnyWithName("AM or PM", I("am"), I("pm"))
+	amPM := gp.NamedRegex("AM or PM", `(?i)(am|pm)\b`)
 
 	colonSecond := gp.Seq(":", second).Map(func(n *gp.Result) {
 		n.Result = n.Child[1].Result
@@ -433,14 +534,17 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		m := n.Child[0].Result.(int)
 		c1 := n.Child[1].Result
 		dur := time.Minute - time.Second
+		g := Minute
 		s := 0
 		if c1 != nil {
 			s = c1.(int)
 			dur = time.Second
+			g = Second
 		}
 		n.Result = Range{
 			time.Date(1, 1, 1, 0, m, s, 0, ref.Location()),
 			dur,
+			g,
 		}
 	})
 
@@ -448,6 +552,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		n.Result = Range{
 			time.Date(ref.Year(), ref.Month(), ref.Day(), 12, 0, 0, 0, ref.Location()),
 			time.Hour - time.Second,
+			Hour,
 		}
 	})
 
@@ -455,6 +560,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		h := n.Child[0].Result.(int)
 		c1 := n.Child[1].Result
 		dur := time.Hour - time.Second
+		g := Hour
 		m := 0
 		s := 0
 		ap := strings.ToLower(n.Child[2].Token)
@@ -471,10 +577,12 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 				panic(err)
 			}
 			dur = ms.Duration
+			g = ms.Granularity
 		}
 		n.Result = Range{
 			time.Date(ref.Year(), ref.Month(), ref.Day(), t.Hour(), t.Minute(), t.Second(), 0, ref.Location()),
 			dur,
+			g,
 		}
 	})
 
@@ -482,15 +590,18 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		h := n.Child[0].Result.(int)
 		m := n.Child[1].Result.(int)
 		dur := time.Minute - time.Second
+		g := Minute
 		s := 0
 		c2 := n.Child[2].Result
 		if c2 != nil {
 			s = c2.(int)
 			dur = time.Second
+			g = Second
 		}
 		n.Result = Range{
 			time.Date(ref.Year(), ref.Month(), ref.Day(), h, m, s, 0, ref.Location()),
 			dur,
+			g,
 		}
 	})
 
@@ -529,7 +640,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 
 	zoneZ := gp.Bind(I("z"), time.UTC)
 
-	zone := gp.AnyWithName("time zone", zoneUTC, zoneOffset, zoneZ).Map(func(n *gp.Result) {
+	zone := gp.AnyWithName("time zone", zoneUTC, zoneOffset, zoneName(o.zonePolicy), zoneZ).Map(func(n *gp.Result) {
 		pass()
 	})
 
@@ -549,6 +660,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		n.Result = Range{
 			time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), 0, ref.Location()),
 			time.Second,
+			Second,
 		}
 	})
 
@@ -561,6 +673,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		n.Result = Range{
 			time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), 0, z),
 			time.Second,
+			Second,
 		}
 	})
 
@@ -573,6 +686,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		n.Result = Range{
 			time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), 0, z),
 			time.Second,
+			Second,
 		}
 	})
 
@@ -581,7 +695,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		if err != nil {
 			panic(fmt.Sprintf("parsing time in RFC3339 format: %v", err))
 		}
-		n.Result = Range{t, time.Second}
+		n.Result = Range{t, time.Second, Second}
 	})
 
 	dmyDate := gp.Seq(dayOfMonth, gp.Maybe(gp.Any(I("of"), sep)), month, sep, year).Map(func(n *gp.Result) {
@@ -591,6 +705,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		n.Result = Range{
 			time.Date(y, m, d, 0, 0, 0, 0, ref.Location()),
 			24*time.Hour - time.Second,
+			Day,
 		}
 	})
 
@@ -601,7 +716,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		d0 := time.Date(y, m, 1, 0, 0, 0, 0, ref.Location())
 		d1 := d0.AddDate(0, 1, 0)
 		dur := d1.Sub(d0) - time.Second
-		n.Result = Range{d0, dur}
+		n.Result = Range{d0, dur, Month}
 	})
 
 	ymDate := gp.Seq(year, month).Map(func(n *gp.Result) {
@@ -610,7 +725,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		d0 := time.Date(y, m, 1, 0, 0, 0, 0, ref.Location())
 		d1 := d0.AddDate(0, 1, 0)
 		dur := d1.Sub(d0) - time.Second
-		n.Result = Range{d0, dur}
+		n.Result = Range{d0, dur, Month}
 	})
 
 	mdyDate := gp.Seq(month, sep, dayOfMonth, sep, year).Map(func(n *gp.Result) {
@@ -620,6 +735,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		n.Result = Range{
 			time.Date(y, m, d, 0, 0, 0, 0, ref.Location()),
 			24*time.Hour - time.Second,
+			Day,
 		}
 	})
 
@@ -630,28 +746,15 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		n.Result = Range{
 			time.Date(y, m, d, 0, 0, 0, 0, ref.Location()),
 			24*time.Hour - time.Second,
+			Day,
 		}
 	})
 
-	ymdNumDate := gp.Seq(year, sladash, monthNum, sladash, dayOfMonthNum).Map(func(n *gp.Result) {
-		y := n.Child[0].Result.(int)
-		m := n.Child[2].Result.(time.Month)
-		d := n.Child[4].Result.(int)
-		n.Result = Range{
-			time.Date(y, m, d, 0, 0, 0, 0, ref.Location()),
-			24*time.Hour - time.Second,
-		}
-	})
+	numDate := numericDate(ref, o)
 
-	dmyNumDate := gp.Seq(dayOfMonthNum, sep, monthNum, sep, year).Map(func(n *gp.Result) {
-		d := n.Child[0].Result.(int)
-		m := n.Child[2].Result.(time.Month)
-		y := n.Child[4].Result.(int)
-		n.Result = Range{
-			time.Date(y, m, d, 0, 0, 0, 0, ref.Location()),
-			24*time.Hour - time.Second,
-		}
-	})
+	weekDate := isoWeekDate(ref)
+
+	weekNum := weekNumber(ref, o.defaultDirection)
 
 	yearEra := gp.Regex(`(?i)[12]\d{3}\s*(ad|ce)\b`).Map(func(n *gp.Result) {
 		s := strings.ToLower(n.Token)
@@ -664,19 +767,20 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		n.Result = Range{
 			d0,
 			dur,
+			Year,
 		}
 	})
 
 	lastWeekday := gp.Seq(I("last"), weekday).Map(func(n *gp.Result) {
 		day := n.Child[1].Result.(time.Weekday)
 		d := prevWeekdayFrom(ref, day)
-		n.Result = Range{d, 24*time.Hour - time.Second}
+		n.Result = Range{d, 24*time.Hour - time.Second, Day}
 	})
 
 	nextWeekday := gp.Seq(I("next"), weekday).Map(func(n *gp.Result) {
 		day := n.Child[1].Result.(time.Weekday)
 		d := nextWeekdayFrom(ref, day)
-		n.Result = Range{d, 24*time.Hour - time.Second}
+		n.Result = Range{d, 24*time.Hour - time.Second, Day}
 	})
 
 	lastSpecificMonthDay := gp.Seq(I("last"), month, dayOfMonth).Map(func(n *gp.Result) {
@@ -684,7 +788,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		d := n.Child[2].Result.(int)
 		pm := prevMonth(ref, m)
 		t := time.Date(pm.Year(), pm.Month(), d, 0, 0, 0, 0, ref.Location())
-		n.Result = Range{t, 24*time.Hour - time.Second}
+		n.Result = Range{t, 24*time.Hour - time.Second, Day}
 	})
 
 	nextSpecificMonthDay := gp.Seq(I("next"), month, dayOfMonth).Map(func(n *gp.Result) {
@@ -692,7 +796,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		d := n.Child[2].Result.(int)
 		nm := nextMonth(ref, m)
 		t := time.Date(nm.Year(), nm.Month(), d, 0, 0, 0, 0, ref.Location())
-		n.Result = Range{t, 24*time.Hour - time.Second}
+		n.Result = Range{t, 24*time.Hour - time.Second, Day}
 	})
 
 	lastYear := gp.Seq(I("last"), I("year")).Map(func(n *gp.Result) {
@@ -707,6 +811,89 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		n.Result = truncateYear(ref.AddDate(1, 0, 0))
 	})
 
+	fiscalYearStart := o.fiscalYearStart
+	if fiscalYearStart == 0 {
+		fiscalYearStart = time.January
+	}
+
+	lastQuarter := gp.Seq(I("last"), I("quarter")).Map(func(n *gp.Result) {
+		n.Result = truncateQuarter(ref.AddDate(0, -3, 0))
+	})
+
+	thisQuarter := gp.Seq(I("this"), I("quarter")).Map(func(n *gp.Result) {
+		n.Result = truncateQuarter(ref)
+	})
+
+	nextQuarter := gp.Seq(I("next"), I("quarter")).Map(func(n *gp.Result) {
+		n.Result = truncateQuarter(ref.AddDate(0, 3, 0))
+	})
+
+	lastFiscalYear := gp.Seq(I("last"), I("fy")).Map(func(n *gp.Result) {
+		n.Result = truncateFiscalYear(ref.AddDate(-1, 0, 0), fiscalYearStart)
+	})
+
+	thisFiscalYear := gp.Seq(I("this"), I("fy")).Map(func(n *gp.Result) {
+		n.Result = truncateFiscalYear(ref, fiscalYearStart)
+	})
+
+	nextFiscalYear := gp.Seq(I("next"), I("fy")).Map(func(n *gp.Result) {
+		n.Result = truncateFiscalYear(ref.AddDate(1, 0, 0), fiscalYearStart)
+	})
+
+	quarterOrHalf := gp.Regex(`(?i)(q[1-4]|h[12])\b`).Map(func(n *gp.Result) {
+		n.Result = parsePeriod(n.Token)
+	})
+
+	fiscalYear := gp.Regex(`(?i)fy\s*-?(\d{4}|\d{2})\b`).Map(func(n *gp.Result) {
+		digits := nonDigitRx.ReplaceAllString(n.Token, "")
+		y, err := strconv.Atoi(digits)
+		if err != nil {
+			panic(fmt.Sprintf("parsing fiscal year: %v", err))
+		}
+		if len(digits) == 2 {
+			y += 2000
+		}
+		n.Result = startOfFiscalYear(y, fiscalYearStart, ref.Location())
+	})
+
+	fiscalYearPeriod := gp.Seq(fiscalYear, gp.Maybe(quarterOrHalf)).Map(func(n *gp.Result) {
+		s := n.Child[0].Result.(time.Time)
+		if p, ok := n.Child[1].Result.(period); ok {
+			n.Result = p.in(s)
+			return
+		}
+		n.Result = truncateFiscalYear(s, fiscalYearStart)
+	})
+
+	periodFiscalYear := gp.Seq(quarterOrHalf, fiscalYear).Map(func(n *gp.Result) {
+		p := n.Child[0].Result.(period)
+		n.Result = p.in(n.Child[1].Result.(time.Time))
+	})
+
+	periodYear := gp.Seq(quarterOrHalf, year).Map(func(n *gp.Result) {
+		p := n.Child[0].Result.(period)
+		y := n.Child[1].Result.(int)
+		n.Result = p.in(time.Date(y, 1, 1, 0, 0, 0, 0, ref.Location()))
+	})
+
+	yearPeriod := gp.Seq(year, quarterOrHalf).Map(func(n *gp.Result) {
+		y := n.Child[0].Result.(int)
+		p := n.Child[1].Result.(period)
+		n.Result = p.in(time.Date(y, 1, 1, 0, 0, 0, 0, ref.Location()))
+	})
+
+	periodNoYear := gp.Seq(quarterOrHalf).Map(func(n *gp.Result) {
+		p := n.Child[0].Result.(period)
+		switch o.defaultDirection {
+		case future:
+			n.Result = p.next(ref)
+		case past:
+			n.Result = p.prev(ref)
+		default:
+			panic(fmt.Sprintf("invalid default direction: %q", o.defaultDirection))
+		}
+	})
+
 	color := gp.AnyWithName("color",
 		I("white"), I("red"), I("green"), I("blue"), I("gold"), I("purple"), I("orange"), I("pink"),
 		I("silver"), I("copper"))
@@ -729,7 +916,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		delta := color2delta[c]
 		t := nextMonth(ref, m)
 		dur := t.AddDate(0, 1, 0).Sub(t.Time) - time.Second
-		n.Result = Range{t.AddDate(delta, 0, 0), dur}
+		n.Result = Range{t.AddDate(delta, 0, 0), dur, Month}
 	})
 
 	monthNoYear := gp.Seq(month, gp.Maybe(dayOfMonth)).Map(func(n *gp.Result) {
@@ -746,6 +933,27 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		n.Result = setDayMaybe(d, n.Child[1].Result)
 	})
 
+	dayMonthNoYear := gp.Seq(gp.Maybe(I("the")), dayOfMonth, gp.Maybe(I("of")), month).Map(func(n *gp.Result) {
+		var d Range
+		m := n.Child[3].Result.(time.Month)
+		switch o.defaultDirection {
+		case future:
+			d = nextMonth(ref, m)
+		case past:
+			d = prevMonth(ref, m)
+		default:
+			panic(fmt.Sprintf("invalid default direction: %q", o.defaultDirection))
+		}
+		day := n.Child[1].Result.(int)
+		n.Result = truncateDay(time.Date(d.Year(), d.Month(), day, 0, 0, 0, 0, ref.Location()))
+	})
+
+	weekdayOfWeek := gp.Seq(weekday, gp.AnyWithName("week", lastWeekParser, thisWeekParser, nextWeekParser)).Map(func(n *gp.Result) {
+		w := n.Child[0].Result.(time.Weekday)
+		week := n.Child[1].Result.(Range)
+		n.Result = truncateDay(week.AddDate(0, 0, (int(w)-int(o.weekStart)+7)%7))
+	})
+
 	weekdayNoDirection := gp.Seq(weekday).Map(func(n *gp.Result) {
 		w := n.Child[0].Result.(time.Weekday)
 		var t time.Time
@@ -757,7 +965,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		default:
 			panic(fmt.Sprintf("invalid default direction: %q", o.defaultDirection))
 		}
-		n.Result = Range{t, 24*time.Hour - time.Second}
+		n.Result = Range{t, 24*time.Hour - time.Second, Day}
 	})
 
 	yesterday := gp.Bind(I("yesterday"), truncateDay(ref.AddDate(0, 0, -1)))
@@ -770,7 +978,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		dy := n.Child[0].Result.(int)
 		y := ref.AddDate(-dy, 0, 0)
 		dur := y.AddDate(1, 0, 0).Sub(y) - time.Second
-		n.Result = Range{y, dur}
+		n.Result = Range{y, dur, Year}
 	})
 
 	fromNowOrToday := gp.Any(I("hence"), gp.Seq(I("from"), gp.Any(I("now"), I("today"))))
@@ -779,7 +987,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		dy := n.Child[0].Result.(int)
 		y := ref.AddDate(dy, 0, 0)
 		dur := y.AddDate(1, 0, 0).Sub(y) - time.Second
-		n.Result = Range{y, dur}
+		n.Result = Range{y, dur, Year}
 	})
 
 	daysLabel := gp.Regex(`(?i)days?`)
@@ -787,13 +995,13 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 	xDaysAgo := gp.Seq(number, daysLabel, I("ago")).Map(func(n *gp.Result) {
 		delta := n.Child[0].Result.(int)
 		d := ref.AddDate(0, 0, -delta)
-		n.Result = Range{d, 24*time.Hour - time.Second}
+		n.Result = Range{d, 24*time.Hour - time.Second, Day}
 	})
 
 	xDaysFromNow := gp.Seq(number, daysLabel, fromNowOrToday).Map(func(n *gp.Result) {
 		delta := n.Child[0].Result.(int)
 		d := ref.AddDate(0, 0, delta)
-		n.Result = Range{d, 24*time.Hour - time.Second}
+		n.Result = Range{d, 24*time.Hour - time.Second, Day}
 	})
 
 	weeksLabel := gp.Regex(`(?i)weeks?`)
@@ -801,31 +1009,116 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 	xWeeksAgo := gp.Seq(number, weeksLabel, I("ago")).Map(func(n *gp.Result) {
 		delta := n.Child[0].Result.(int)
 		d := ref.AddDate(0, 0, -7*delta)
-		n.Result = Range{d, 7*24*time.Hour - time.Second}
+		n.Result = Range{d, 7*24*time.Hour - time.Second, Week}
 	})
 
 	xWeeksFromNow := gp.Seq(number, weeksLabel, fromNowOrToday).Map(func(n *gp.Result) {
 		delta := n.Child[0].Result.(int)
 		d := ref.AddDate(0, 0, 7*delta)
-		n.Result = Range{d, 7*24*time.Hour - time.Second}
+		n.Result = Range{d, 7*24*time.Hour - time.Second, Week}
+	})
+
+	inDateUnits, withinDateUnits := inAndWithinUnits(ref, number, []unitRange{
+		{daysLabel, func(num int) Range {
+			return Range{ref.AddDate(0, 0, num), 24*time.Hour - time.Second, Day}
+		}},
+		{weeksLabel, func(num int) Range {
+			return Range{ref.AddDate(0, 0, 7*num), 7*24*time.Hour - time.Second, Week}
+		}},
+		{months, func(num int) Range {
+			s := ref.AddDate(0, num, 0)
+			return Range{s, s.AddDate(0, 1, 0).Sub(s) - time.Second, Month}
+		}},
+		{yearsLabel, func(num int) Range {
+			s := ref.AddDate(num, 0, 0)
+			return Range{s, s.AddDate(1, 0, 0).Sub(s) - time.Second, Year}
+		}},
+	})
+
+	kindOfDay := gp.AnyWithName("kind of day",
+		weekday.Map(func(n *gp.Result) {
+			wd := n.Result.(time.Weekday)
+			n.Result = func(t time.Time) bool { return t.Weekday() == wd }
+		}),
+		gp.Seq(I("business"), I("day")).Map(func(n *gp.Result) {
+			n.Result = isBusinessDay
+		}),
+		I("weekday").Map(func(n *gp.Result) {
+			n.Result = isBusinessDay
+		}),
+		I("day").Map(func(n *gp.Result) {
+			n.Result = func(time.Time) bool { return true }
+		}))
+
+	namedUnit := gp.Seq(gp.Maybe(I("the")), gp.Regex(`(?i)(week|month|quarter|year)\b`)).Map(func(n *gp.Result) {
+		switch strings.ToLower(n.Child[1].Token) {
+		case "week":
+			n.Result = thisWeek(ref, o.weekStart)
+		case "month":
+			n.Result = truncateMonth(ref)
+		case "quarter":
+			n.Result = truncateQuarter(ref)
+		case "year":
+			n.Result = truncateYear(ref)
+		}
+	})
+
+	monthOnly := gp.Seq(month).Map(func(n *gp.Result) {
+		m := n.Child[0].Result.(time.Month)
+		switch o.defaultDirection {
+		case future:
+			n.Result = nextMonth(ref, m)
+		case past:
+			n.Result = prevMonth(ref, m)
+		default:
+			panic(fmt.Sprintf("invalid default direction: %q", o.defaultDirection))
+		}
+	})
+
+	yearOnly := gp.Seq(year).Map(func(n *gp.Result) {
+		y := n.Child[0].Result.(int)
+		n.Result = truncateYear(time.Date(y, 1, 1, 0, 0, 0, 0, ref.Location()))
+	})
+
+	period := gp.AnyWithName("period",
+		namedUnit, myDate,
+		lastSpecificMonth, nextSpecificMonth,
+		lastYear, thisYear, nextYear,
+		lastQuarter, thisQuarter, nextQuarter,
+		lastFiscalYear, thisFiscalYear, nextFiscalYear,
+		fiscalYearPeriod, periodFiscalYear, periodYear, yearPeriod, periodNoYear,
+		nextMo, thisMo, prevMo,
+		lastWeekParser, thisWeekParser, nextWeekParser,
+		monthOnly, yearOnly)
+
+	ordinalDay := ordinalDayOf(kindOfDay, period, o)
+
+	partOfPeriod := gp.Seq(periodPart(o.portions), period).Map(func(n *gp.Result) {
+		part := n.Child[0].Result.(func(Range) Range)
+		n.Result = part(n.Child[1].Result.(Range))
 	})
 
 	date := gp.AnyWithName("date",
 		yesterday, today, tomorrow,
+		ordinalDay, partOfPeriod,
 		ymdDate, dmyDate, mdyDate, myDate, ymDate,
-		ymdNumDate, dmyNumDate,
+		weekDate, weekNum, numDate,
 		lastSpecificMonthDay, nextSpecificMonthDay,
 		lastSpecificMonth, nextSpecificMonth,
 		lastYear, thisYear, nextYear,
+		lastQuarter, thisQuarter, nextQuarter,
+		lastFiscalYear, thisFiscalYear, nextFiscalYear,
+		fiscalYearPeriod, periodFiscalYear, periodYear, yearPeriod, periodNoYear,
 		nextMo, thisMo, prevMo,
 		lastWeekday, nextWeekday,
 		lastWeekParser, thisWeekParser, nextWeekParser,
-		colorMonth, monthNoYear,
-		weekdayNoDirection, yearEra,
+		colorMonth, monthNoYear, dayMonthNoYear,
+		weekdayOfWeek, weekdayNoDirection, yearEra,
 		xDaysAgo, xDaysFromNow,
 		xWeeksAgo, xWeeksFromNow,
 		monthsAgo, monthsFromNow,
-		xYearsAgo, xYearsFromToday)
+		xYearsAgo, xYearsFromToday,
+		inDateUnits)
 
 	on := gp.Regex(`(?i)\bon\b`)
 	onDate := gp.Seq(gp.Maybe(on), date).Map(func(n *gp.Result) {
@@ -845,6 +1138,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		n.Result = Range{
 			setLocation(t.Time, z),
 			t.Duration,
+			t.Granularity,
 		}
 	})
 
@@ -865,6 +1159,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 			Range{
 				setLocation(d.Time, z),
 				d.Duration,
+				d.Granularity,
 			}
 	})
 
@@ -875,6 +1170,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		n.Result = Range{
 			ref.Add(-time.Duration(m) * time.Minute),
 			time.Minute - time.Second,
+			Minute,
 		}
 	})
 
@@ -885,6 +1181,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		n.Result = Range{
 			ref.Add(time.Duration(m) * time.Minute),
 			time.Minute - time.Second,
+			Minute,
 		}
 	})
 
@@ -895,6 +1192,7 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		n.Result = Range{
 			ref.Add(-time.Duration(h) * time.Hour),
 			time.Hour - time.Second,
+			Hour,
 		}
 	})
 
@@ -903,43 +1201,97 @@ func Parser(ref time.Time, options ...func(o *opts)) gp.Parser {
 		n.Result = Range{
 			ref.Add(time.Duration(h) * time.Hour),
 			time.Hour - time.Second,
+			Hour,
 		}
 	})
 
+	inClockUnits, withinClockUnits := inAndWithinUnits(ref, number, []unitRange{
+		{minutesLabel, func(num int) Range {
+			return Range{ref.Add(time.Duration(num) * time.Minute), time.Minute - time.Second, Minute}
+		}},
+		{hoursLabel, func(num int) Range {
+			return Range{ref.Add(time.Duration(num) * time.Hour), time.Hour - time.Second, Hour}
+		}},
+	})
+
+	eod := endOfDay(ref, o.endOfDayHour, onDate)
+
+	partOfDay := dayPartAlone(ref, o.dayParts)
+
+	onDatePartOfDay := gp.Seq(singleDay(onDate), dayPart(o.dayParts)).Map(func(n *gp.Result) {
+		p := n.Child[1].Result.(DayPart)
+		n.Result = p.on(n.Child[0].Result.(Range))
+	})
+
 	return gp.AnyWithName("natural date",
-		now,
+		now, eod, partOfDay,
 		ansiC, rubyDate, rfc1123Z, rfc3339,
-		onDateZone, atTimeOnDate, onDateAtTime,
+		onDateZone, atTimeOnDate, onDateAtTime, onDatePartOfDay,
 		onDate, atTimeWithMaybeZone,
 		xMinutesAgo, xMinutesFromNow,
 		xHoursAgo, xHoursFromNow,
-		hourMinuteSecond).Map(func(n *gp.Result) {
+		inClockUnits, withinClockUnits,
+		withinDateUnits,
+		hourMinuteSecond, bareNumber(ref, o)).Map(func(n *gp.Result) {
 		r := n.Result.(Range)
 		pass(r)
 	})
 }
 
-func thisWeek(ref time.Time) Range {
-	return truncateWeek(ref)
+// unitRange pairs the parser for a unit label such as "days" with a func
+// giving the range of one such unit starting num units after the reference
+// time.
+type unitRange struct {
+	label gp.Parserish
+	after func(num int) Range
+}
+
+// inAndWithinUnits returns parsers for "in N units", meaning the unit N units
+// after ref, and "within N units", meaning the span from ref until N units
+// later, for each of the given units.
+func inAndWithinUnits(ref time.Time, number gp.Parser, units []unitRange) (in, within gp.Parser) {
+	inWord := gp.Regex(`(?i)\bin\b`)
+	withinWord := gp.Regex(`(?i)\bwithin\b`)
+	var ins, withins []gp.Parserish
+	for _, u := range units {
+		after := u.after
+		ins = append(ins, gp.Seq(inWord, number, u.label).Map(func(n *gp.Result) {
+			num := n.Child[1].Result.(int)
+			n.Result = after(num)
+		}))
+		withins = append(withins, gp.Seq(withinWord, number, u.label).Map(func(n *gp.Result) {
+			num := n.Child[1].Result.(int)
+			a := after(num)
+			r := RangeFromTimes(ref, a.Time)
+			r.Granularity = a.Granularity
+			n.Result = r
+		}))
+	}
+	return gp.AnyWithName("in x units", ins...), gp.AnyWithName("within x units", withins...)
 }
 
-func lastWeek(ref time.Time) Range {
+func thisWeek(ref time.Time, first time.Weekday) Range {
+	return truncateWeek(ref, first)
+}
+
+func lastWeek(ref time.Time, first time.Weekday) Range {
 	minus7 := ref.AddDate(0, 0, -7)
-	return truncateWeek(minus7)
+	return truncateWeek(minus7, first)
 }
 
-func nextWeek(ref time.Time) Range {
+func nextWeek(ref time.Time, first time.Weekday) Range {
 	plus7 := ref.AddDate(0, 0, 7)
-	return truncateWeek(plus7)
+	return truncateWeek(plus7, first)
 }
 
 // ParseRange parses a string such as "from april 20 at 5pm to may 5 at 9pm"
 // and returns a Range.
 func ParseRange(s string, ref time.Time, opts ...func(o *opts)) (Range, error) {
-	p := RangeParser(ref, opts...)
+	var rejection error
+	p := RangeParser(ref, withOptions(opts, noteRejection(&rejection))...)
 	result, _, err := gp.Run(p, s, gp.UnicodeWhitespace)
 	if err != nil {
-		return Range{}, fmt.Errorf("running range parser: %w", err)
+		return Range{}, fmt.Errorf("running range parser: %w", rejectionError(rejection, err))
 	}
 	r := result.(Range)
 	return r, nil
@@ -947,22 +1299,49 @@ func ParseRange(s string, ref time.Time, opts ...func(o *opts)) (Range, error) {
 
 // RangeParser takes a reference time ref and returns a parser for date ranges.
 func RangeParser(ref time.Time, options ...func(o *opts)) gp.Parser {
-	preposition := gp.AnyWithName("a preposition such as to or until", I("to"), I("until"), I("through"), I("til"), I("'til"), I("till"))
+	preposition := gp.AnyWithName("a preposition such as to or until", I("to"), I("until"), I("through"), I("til"), I("'til"), I("till"), I("–"), I("—"))
 	toPart := gp.Seq(preposition, Parser(ref, options...)).Map(func(n *gp.Result) {
 		n.Result = n.Child[1].Result
 	})
-	return gp.Seq(gp.Maybe(I("from")), Parser(ref, options...), gp.Maybe(toPart)).Map(func(n *gp.Result) {
+	plain := gp.Seq(gp.Maybe(I("from")), Parser(ref, options...), gp.Maybe(toPart)).Map(func(n *gp.Result) {
 		s := n.Child[1].Result.(Range)
 		c2 := n.Child[2].Result
 		if c2 != nil {
 			// This is an explicit range like "from A until B"
 			e := c2.(Range)
 			dur := e.Sub(s.Time)
-			n.Result = Range{s.Time, dur}
+			n.Result = Range{s.Time, dur, finer(s.Granularity, e.Granularity)}
 			return
 		}
 		n.Result = s
 	})
+	shared := sharedRange(Parser(ref, options...))
+
+	// Ranges whose ends share things, as in "march 3-5, 2022", are taken over
+	// the reading of their ends on their own if they are longer, or as long
+	// and that reading is also an explicit range, as with "9am to 5pm on
+	// tuesday". Otherwise, expressions like "second to last friday" would be
+	// split at the "to".
+	return func(ps *gp.State, node *gp.Result) {
+		start := ps.Pos
+		plain(ps, node)
+		plainPos, plainOK := ps.Pos, !ps.Errored()
+		explicit := plainOK && node.Child[2].Result != nil
+		plainResult := *node
+		ps.Pos = start
+		ps.Recover()
+		shared(ps, node)
+		if !ps.Errored() && (!plainOK || ps.Pos > plainPos || (ps.Pos == plainPos && explicit)) {
+			return
+		}
+		ps.Recover()
+		*node = plainResult
+		ps.Pos = plainPos
+		if !plainOK {
+			ps.Pos = start
+			plain(ps, node)
+		}
+	}
 }
 
 func setTimeMaybe(datePart Range, timePart any) Range {
@@ -974,6 +1353,7 @@ func setTimeMaybe(datePart Range, timePart any) Range {
 	return Range{
 		time.Date(d.Year(), d.Month(), d.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()),
 		t.Duration,
+		t.Granularity,
 	}
 }
 
@@ -985,6 +1365,7 @@ func setDayMaybe(t Range, dayAsAny any) Range {
 	return Range{
 		time.Date(t.Year(), t.Month(), d, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()),
 		24 * time.Hour,
+		Day,
 	}
 }
 
@@ -1042,7 +1423,7 @@ func nextMonth(t time.Time, month time.Month) Range {
 	d := time.Date(y, month, 1, 0, 0, 0, 0, t.Location())
 	e := d.AddDate(0, 1, 0).Add(-time.Second)
 	dur := e.Sub(d)
-	return Range{d, dur}
+	return Range{d, dur, Month}
 }
 
 // prevMonth returns the next month relative to time t.
@@ -1054,7 +1435,7 @@ func prevMonth(t time.Time, month time.Month) Range {
 	d := time.Date(y, month, 1, 0, 0, 0, 0, t.Location())
 	e := d.AddDate(0, 1, 0).Add(-time.Second)
 	dur := e.Sub(d)
-	return Range{d, dur}
+	return Range{d, dur, Month}
 }
 
 // truncateDay returns a date truncated to the day.
@@ -1062,17 +1443,18 @@ func truncateDay(t time.Time) Range {
 	y, m, d := t.Date()
 	s := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
 	e := s.AddDate(0, 0, 1).Add(-time.Second)
-	return Range{s, e.Sub(s)}
+	return Range{s, e.Sub(s), Day}
 }
 
-// truncateWeek returns a date truncated to the week.
-func truncateWeek(t time.Time) Range {
-	for t.Weekday() != time.Sunday {
+// truncateWeek returns a date truncated to the week, taking weeks to start on
+// the day first.
+func truncateWeek(t time.Time, first time.Weekday) Range {
+	for t.Weekday() != first {
 		t = t.AddDate(0, 0, -1)
 	}
 	s := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
 	e := s.AddDate(0, 0, 7).Add(-time.Second)
-	return Range{s, e.Sub(s)}
+	return Range{s, e.Sub(s), Week}
 }
 
 // truncateMonth returns a date truncated to the month.
@@ -1080,14 +1462,42 @@ func truncateMonth(t time.Time) Range {
 	y, m, _ := t.Date()
 	s := time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
 	e := s.AddDate(0, 1, 0).Add(-time.Second)
-	return Range{s, e.Sub(s)}
+	return Range{s, e.Sub(s), Month}
+}
+
+// truncateQuarter returns a date truncated to the calendar quarter.
+func truncateQuarter(t time.Time) Range {
+	y, m, _ := t.Date()
+	s := time.Date(y, m-(m-1)%3, 1, 0, 0, 0, 0, t.Location())
+	e := s.AddDate(0, 3, 0).Add(-time.Second)
+	return Range{s, e.Sub(s), Quarter}
+}
+
+// truncateHalf returns a date truncated to the half of the calendar year.
+func truncateHalf(t time.Time) Range {
+	y, m, _ := t.Date()
+	s := time.Date(y, m-(m-1)%6, 1, 0, 0, 0, 0, t.Location())
+	e := s.AddDate(0, 6, 0).Add(-time.Second)
+	return Range{s, e.Sub(s), Half}
+}
+
+// truncateFiscalYear returns a date truncated to the fiscal year starting in
+// the month first.
+func truncateFiscalYear(t time.Time, first time.Month) Range {
+	y := t.Year()
+	if t.Month() < first {
+		y--
+	}
+	s := time.Date(y, first, 1, 0, 0, 0, 0, t.Location())
+	e := s.AddDate(1, 0, 0).Add(-time.Second)
+	return Range{s, e.Sub(s), Year}
 }
 
 // truncateYear returns a date truncated to the year.
 func truncateYear(t time.Time) Range {
 	s := time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location())
 	e := s.AddDate(1, 0, 0).Add(-time.Second)
-	return Range{s, e.Sub(s)}
+	return Range{s, e.Sub(s), Year}
 }
 
 // setTime takes the date from d and the time from the remaining args and
@@ -1,6 +1,7 @@
 package anytime
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"math/rand"
@@ -147,6 +148,16 @@ func TestParse_goodTimes(t *testing.T) {
 		{`1999 AD`, time.Date(1999, 1, 1, 0, 0, 0, 0, now.Location())},
 		{`2008CE`, time.Date(2008, 1, 1, 0, 0, 0, 0, now.Location())},
 		{`2008 CE`, time.Date(2008, 1, 1, 0, 0, 0, 0, now.Location())},
+
+		// in N units
+		{`in 5 minutes`, now.Add(5 * time.Minute)},
+		{`in an hour`, now.Add(time.Hour)},
+		{`in 3 days`, now.AddDate(0, 0, 3)},
+		{`In two weeks`, now.AddDate(0, 0, 14)},
+		{`in a month`, now.AddDate(0, 1, 0)},
+		{`in 2 years`, now.AddDate(2, 0, 0)},
+		{`in 3 days at 5pm`, dateAtTime(now.AddDate(0, 0, 3), 12+5, 0, 0)},
+		{`within 3 days`, now},
 	}
 
 	for _, c := range cases {
@@ -169,6 +180,52 @@ func TestParse_goodTimes(t *testing.T) {
 	}
 }
 
+func TestParse_zoneNames(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tomorrow := truncateDay(now.AddDate(0, 0, 1)).Time
+	var cases = []struct {
+		Input    string
+		Options  []func(o *opts)
+		WantTime time.Time
+	}{
+		{"tomorrow at 5pm PST", nil, timeInLocation(dateAtTime(tomorrow, 17, 0, 0), time.FixedZone("PST", -8*60*60))},
+		{"tomorrow at 5pm pdt", nil, timeInLocation(dateAtTime(tomorrow, 17, 0, 0), time.FixedZone("PDT", -7*60*60))},
+		{"September 17, 2012 at 10am CET", nil, time.Date(2012, 9, 17, 10, 0, 0, 0, fixedZone(1))},
+		{"September 17, 2012 at 9:00 America/New_York", nil, time.Date(2012, 9, 17, 9, 0, 0, 0, ny)},
+		{"September 17, 2012 at noon Pacific time", nil, time.Date(2012, 9, 17, 12, 0, 0, 0, la)},
+		{"September 17, 2012 at noon pacific standard time", nil, time.Date(2012, 9, 17, 12, 0, 0, 0, fixedZone(-8))},
+		{"September 17, 2012 IST", nil, time.Date(2012, 9, 17, 0, 0, 0, 0, fixedZoneHM(5, 30))},
+		{"September 17, 2012 IST", []func(o *opts){WithZonePolicy(PreferZones("Irish Standard Time"))}, time.Date(2012, 9, 17, 0, 0, 0, 0, fixedZone(1))},
+		{"September 17, 2012 at 5pm CST", nil, time.Date(2012, 9, 17, 17, 0, 0, 0, fixedZone(-6))},
+		{"September 17, 2012 at 5pm CST", []func(o *opts){WithZonePolicy(PreferZones("China Standard Time"))}, time.Date(2012, 9, 17, 17, 0, 0, 0, fixedZone(8))},
+	}
+	for _, c := range cases {
+		t.Run(c.Input, func(t *testing.T) {
+			v, err := Parse(c.Input, now, c.Options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !v.Equal(c.WantTime) {
+				t.Errorf("got %v, want %v", v, c.WantTime)
+			}
+		})
+	}
+
+	t.Run("rejected", func(t *testing.T) {
+		v, err := Parse("September 17, 2012 at 5pm CST", now, WithZonePolicy(RejectAmbiguousZones))
+		if err == nil {
+			t.Errorf("err is nil, result is %v", v)
+		}
+	})
+}
+
 func TestParse_goodDays(t *testing.T) {
 	var cases = []struct {
 		Input    string
@@ -188,8 +245,8 @@ func TestParse_goodDays(t *testing.T) {
 		{`Tomorrow`, now.AddDate(0, 0, 1)},
 
 		// weeks
-		{`Last week`, truncateWeek(now.AddDate(0, 0, -7)).Time},
-		{`Next week`, truncateWeek(now.AddDate(0, 0, 7)).Time},
+		{`Last week`, truncateWeek(now.AddDate(0, 0, -7), time.Sunday).Time},
+		{`Next week`, truncateWeek(now.AddDate(0, 0, 7), time.Sunday).Time},
 
 		// past weekdays
 		{`Last sunday`, prevWeekdayFrom(now, time.Sunday)},
@@ -518,7 +575,8 @@ func TestParse_bad(t *testing.T) {
 
 func Test_truncateWeek(t *testing.T) {
 	type args struct {
-		t time.Time
+		t     time.Time
+		first time.Weekday
 	}
 	tests := []struct {
 		name string
@@ -546,16 +604,46 @@ func Test_truncateWeek(t *testing.T) {
 			},
 			want: time.Date(2022, 10, 9, 0, 0, 0, 0, time.UTC),
 		},
+		{
+			name: "Sunday in a week starting on monday",
+			args: args{
+				t:     time.Date(2022, 10, 2, 23, 59, 59, 999999, time.UTC),
+				first: time.Monday,
+			},
+			want: time.Date(2022, 9, 26, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "monday in a week starting on monday",
+			args: args{
+				t:     time.Date(2022, 10, 3, 23, 59, 59, 999999, time.UTC),
+				first: time.Monday,
+			},
+			want: time.Date(2022, 10, 3, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "saturday in a week starting on saturday",
+			args: args{
+				t:     time.Date(2022, 10, 15, 23, 59, 59, 999999, time.UTC),
+				first: time.Saturday,
+			},
+			want: time.Date(2022, 10, 15, 0, 0, 0, 0, time.UTC),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := truncateWeek(tt.args.t).Time; !reflect.DeepEqual(got, tt.want) {
+			if got := truncateWeek(tt.args.t, tt.args.first).Time; !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("truncateWeek() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
 
+// rangeWithGranularity returns the range from start to end with the given
+// granularity.
+func rangeWithGranularity(start, end time.Time, g Granularity) Range {
+	return Range{start, end.Sub(start), g}
+}
+
 func TestParseRange(t *testing.T) {
 	tests := []struct {
 		input string
@@ -564,262 +652,317 @@ func TestParseRange(t *testing.T) {
 		// from A to B
 		{
 			"From 3 feb 2022 to 6 oct 2022",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 2, 3, 0, 0, 0, 0, now.Location()),
 				time.Date(2022, 10, 6, 0, 0, 0, 0, now.Location()),
+				Day,
 			),
 		},
 		// A to B
 		{
 			"3 feb 2022 to 6 oct 2022",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 2, 3, 0, 0, 0, 0, now.Location()),
 				time.Date(2022, 10, 6, 0, 0, 0, 0, now.Location()),
+				Day,
 			),
 		},
 		// A through B
 		{
 			"3 feb 2022 through 6 oct 2022",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 2, 3, 0, 0, 0, 0, now.Location()),
 				time.Date(2022, 10, 6, 0, 0, 0, 0, now.Location()),
+				Day,
 			),
 		},
 		// from A until B
 		{
 			"from 3 feb 2022 until 6 oct 2022",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 2, 3, 0, 0, 0, 0, now.Location()),
 				time.Date(2022, 10, 6, 0, 0, 0, 0, now.Location()),
+				Day,
 			),
 		},
 		{
 			"from tuesday at 5pm -12:00 until thursday 23:52 +14:00",
-			RangeFromTimes(
+			rangeWithGranularity(
 				setLocation(setTime(nextWeekdayFrom(now, time.Tuesday), 12+5, 0, 0, 0), fixedZone(-12)),
 				setLocation(setTime(nextWeekdayFrom(now, time.Thursday), 23, 52, 0, 0), fixedZone(14)),
+				Minute,
 			),
 		},
 		// yesterday
 		{
 			"Yesterday",
-			RangeFromTimes(
+			rangeWithGranularity(
 				today.AddDate(0, 0, -1),
 				today.Add(-time.Second),
+				Day,
 			),
 		},
 		// today
 		{
 			"Today",
-			RangeFromTimes(
+			rangeWithGranularity(
 				today,
 				today.AddDate(0, 0, 1).Add(-time.Second),
+				Day,
 			),
 		},
 		// tomorrow
 		{
 			"Tomorrow",
-			RangeFromTimes(
+			rangeWithGranularity(
 				today.AddDate(0, 0, 1),
 				today.AddDate(0, 0, 2).Add(-time.Second),
+				Day,
 			),
 		},
 		{
 			"From today until next thursday",
-			RangeFromTimes(
+			rangeWithGranularity(
 				today,
 				nextWeekdayFrom(today, time.Thursday),
+				Day,
 			),
 		},
 		{
 			"From tomorrow until next tuesday",
-			RangeFromTimes(
+			rangeWithGranularity(
 				today.AddDate(0, 0, 1),
 				nextWeekdayFrom(today, time.Tuesday),
+				Day,
 			),
 		},
 		// last week
 		{
 			"Last week",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 9, 18, 0, 0, 0, 0, now.Location()),
 				time.Date(2022, 9, 25, 0, 0, 0, 0, now.Location()).Add(-time.Second),
+				Week,
 			),
 		},
 		// this week
 		{
 			"This week",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 9, 25, 0, 0, 0, 0, now.Location()),
 				time.Date(2022, 10, 2, 0, 0, 0, 0, now.Location()).Add(-time.Second),
+				Week,
 			),
 		},
 		// next week
 		{
 			"next week",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 10, 2, 0, 0, 0, 0, now.Location()),
 				time.Date(2022, 10, 9, 0, 0, 0, 0, now.Location()).Add(-time.Second),
+				Week,
 			),
 		},
 		// last month
 		{
 			"Last month",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 8, 1, 0, 0, 0, 0, now.Location()),
 				time.Date(2022, 9, 1, 0, 0, 0, 0, now.Location()).Add(-time.Second),
+				Month,
 			),
 		},
 		// this month
 		{
 			"This month",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 9, 1, 0, 0, 0, 0, now.Location()),
 				time.Date(2022, 10, 1, 0, 0, 0, 0, now.Location()).Add(-time.Second),
+				Month,
 			),
 		},
 		// next month
 		{
 			"Next month",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 10, 1, 0, 0, 0, 0, now.Location()),
 				time.Date(2022, 11, 1, 0, 0, 0, 0, now.Location()).Add(-time.Second),
+				Month,
 			),
 		},
 		// last year
 		{
 			"Last year",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2021, 1, 1, 0, 0, 0, 0, now.Location()),
 				time.Date(2022, 1, 1, 0, 0, 0, 0, now.Location()).Add(-time.Second),
+				Year,
 			),
 		},
 		// this year
 		{
 			"This year",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 1, 1, 0, 0, 0, 0, now.Location()),
 				time.Date(2023, 1, 1, 0, 0, 0, 0, now.Location()).Add(-time.Second),
+				Year,
 			),
 		},
 		// next year
 		{
 			"Next year",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2023, 1, 1, 0, 0, 0, 0, now.Location()),
 				time.Date(2024, 1, 1, 0, 0, 0, 0, now.Location()).Add(-time.Second),
+				Year,
 			),
 		},
 		// absolute year
 		{
 			"2025Ad",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2025, 1, 1, 0, 0, 0, 0, now.Location()),
 				time.Date(2026, 1, 1, 0, 0, 0, 0, now.Location()).Add(-time.Second),
+				Year,
 			),
 		},
 		// absolute month
 		{
 			"Feb 2025",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2025, 2, 1, 0, 0, 0, 0, now.Location()),
 				time.Date(2025, 3, 1, 0, 0, 0, 0, now.Location()).Add(-time.Second),
+				Month,
 			),
 		},
 		// absolute day
 		{
 			"3 feb 2025",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2025, 2, 3, 0, 0, 0, 0, now.Location()),
 				time.Date(2025, 2, 4, 0, 0, 0, 0, now.Location()).Add(-time.Second),
+				Day,
 			),
 		},
 		// absolute hour
 		{
 			"3 feb 2025 at 5PM",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2025, 2, 3, 12+5, 0, 0, 0, now.Location()),
 				time.Date(2025, 2, 3, 12+5+1, 0, 0, 0, now.Location()).Add(-time.Second),
+				Hour,
 			),
 		},
 		// absolute minute
 		{
 			"3 feb 2025 at 5:35pm",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2025, 2, 3, 12+5, 35, 0, 0, now.Location()),
 				time.Date(2025, 2, 3, 12+5, 36, 0, 0, now.Location()).Add(-time.Second),
+				Minute,
 			),
 		},
 		// absolute second
 		{
 			"3 Feb 2025 at 5:35:52pm",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2025, 2, 3, 12+5, 35, 52, 0, now.Location()),
 				time.Date(2025, 2, 3, 12+5, 35, 53, 0, now.Location()),
+				Second,
 			),
 		},
 		// 2022 jan 1 0:0:0
 		{
 			"2022 jan 1 0:0:0",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 1, 1, 0, 0, 0, 0, now.Location()),
 				time.Date(2022, 1, 1, 0, 0, 1, 0, now.Location()),
+				Second,
 			),
 		},
 		// 2022 jan 1 0:0
 		{
 			"2022 jan 1 0:0",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 1, 1, 0, 0, 0, 0, now.Location()),
 				time.Date(2022, 1, 1, 0, 1, 0, 0, now.Location()).Add(-time.Second),
+				Minute,
 			),
 		},
 		// 2022 jan 1 12am
 		{
 			"2022 jan 1 12am",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 1, 1, 0, 0, 0, 0, now.Location()),
 				time.Date(2022, 1, 1, 1, 0, 0, 0, now.Location()).Add(-time.Second),
+				Hour,
 			),
 		},
 		// 2022 jan 1 0am
 		{
 			"2022 jan 1 0am",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 1, 1, 0, 0, 0, 0, now.Location()),
 				time.Date(2022, 1, 1, 1, 0, 0, 0, now.Location()).Add(-time.Second),
+				Hour,
 			),
 		},
 		// 2022 jan 1
 		{
 			"2022 jan 1",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 1, 1, 0, 0, 0, 0, now.Location()),
 				time.Date(2022, 1, 2, 0, 0, 0, 0, now.Location()).Add(-time.Second),
+				Day,
 			),
 		},
 		// 2022 jan
 		{
 			"2022 jan",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 1, 1, 0, 0, 0, 0, now.Location()),
 				time.Date(2022, 2, 1, 0, 0, 0, 0, now.Location()).Add(-time.Second),
+				Month,
 			),
 		},
+		// in N units
+		{
+			"in 3 days",
+			Range{now.AddDate(0, 0, 3), 24*time.Hour - time.Second, Day},
+		},
+		{
+			"in an hour",
+			Range{now.Add(time.Hour), time.Hour - time.Second, Hour},
+		},
+		// within N units
+		{
+			"within 3 days",
+			rangeWithGranularity(now, now.AddDate(0, 0, 3), Day),
+		},
+		{
+			"within two weeks",
+			rangeWithGranularity(now, now.AddDate(0, 0, 14), Week),
+		},
+		{
+			"within 90 minutes",
+			rangeWithGranularity(now, now.Add(90*time.Minute), Minute),
+		},
 		// 2022
 		{
 			"2022ce",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 1, 1, 0, 0, 0, 0, now.Location()),
 				time.Date(2023, 1, 1, 0, 0, 0, 0, now.Location()).Add(-time.Second),
+				Year,
 			),
 		},
 		// 2022
 		{
 			"2022CE",
-			RangeFromTimes(
+			rangeWithGranularity(
 				time.Date(2022, 1, 1, 0, 0, 0, 0, now.Location()),
 				time.Date(2023, 1, 1, 0, 0, 0, 0, now.Location()).Add(-time.Second),
+				Year,
 			),
 		},
 	}
@@ -861,20 +1004,362 @@ func Test_nextWeek(t *testing.T) {
 				ref: now,
 			},
 			want: Range{
-				Time:     time.Date(2022, 10, 2, 0, 0, 0, 0, now.Location()),
-				Duration: 7*24*time.Hour - time.Second,
+				Time:        time.Date(2022, 10, 2, 0, 0, 0, 0, now.Location()),
+				Duration:    7*24*time.Hour - time.Second,
+				Granularity: Week,
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := nextWeek(tt.args.ref); !reflect.DeepEqual(got, tt.want) {
+			if got := nextWeek(tt.args.ref, time.Sunday); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("nextWeek() = \n%v\nwant\n%v", got, tt.want)
 			}
 		})
 	}
 }
 
+func TestParseRange_weekStartsOn(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Time
+	}{
+		{"this week", time.Date(2022, 9, 26, 0, 0, 0, 0, time.UTC)},
+		{"last week", time.Date(2022, 9, 19, 0, 0, 0, 0, time.UTC)},
+		{"next week", time.Date(2022, 10, 3, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRange(tt.input, now, WeekStartsOn(time.Monday))
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := Range{tt.want, 7*24*time.Hour - time.Second, Week}
+			if got != want {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestParseRange_granularity(t *testing.T) {
+	tests := []struct {
+		input string
+		want  Granularity
+	}{
+		{"now", Second},
+		{"2006-01-02T15:04:05Z", Second},
+		{"5:30:10pm", Second},
+		{"10:30am", Minute},
+		{"in 5 minutes", Minute},
+		{"5pm tomorrow", Hour},
+		{"an hour ago", Hour},
+		{"noon", Hour},
+		{"today", Day},
+		{"next friday", Day},
+		{"march 3 2022", Day},
+		{"2014/3/31", Day},
+		{"3 days ago", Day},
+		{"within 3 days", Day},
+		{"last week", Week},
+		{"2 weeks from now", Week},
+		{"march 2023", Month},
+		{"red october", Month},
+		{"next month", Month},
+		{"this year", Year},
+		{"1999 AD", Year},
+		{"from march 1 2022 to april 1 2022", Day},
+		{"from last year to 5pm", Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			r, err := ParseRange(tt.input, now, DefaultToFuture)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Granularity != tt.want {
+				t.Errorf("granularity = %v, want %v", r.Granularity, tt.want)
+			}
+		})
+	}
+}
+
+func TestGranularity_String(t *testing.T) {
+	tests := []struct {
+		g    Granularity
+		want string
+	}{
+		{Second, "second"},
+		{Minute, "minute"},
+		{Hour, "hour"},
+		{Day, "day"},
+		{Week, "week"},
+		{Month, "month"},
+		{Quarter, "quarter"},
+		{Half, "half"},
+		{Year, "year"},
+		{Granularity(-1), "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.g.String(); got != tt.want {
+				t.Errorf("String() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_dateOrder(t *testing.T) {
+	var cases = []struct {
+		Input    string
+		Order    DateOrder
+		WantTime time.Time
+	}{
+		{"31/03/2022", NoDateOrder, time.Date(2022, 3, 31, 0, 0, 0, 0, now.Location())},
+		{"03/31/2022", NoDateOrder, time.Date(2022, 3, 31, 0, 0, 0, 0, now.Location())},
+		{"04.04.2022", NoDateOrder, time.Date(2022, 4, 4, 0, 0, 0, 0, now.Location())},
+		{"2022.03.04", NoDateOrder, time.Date(2022, 3, 4, 0, 0, 0, 0, now.Location())},
+		{"03/04/2022", DMY, time.Date(2022, 4, 3, 0, 0, 0, 0, now.Location())},
+		{"03.04.2022", DMY, time.Date(2022, 4, 3, 0, 0, 0, 0, now.Location())},
+		{"03/04/2022", MDY, time.Date(2022, 3, 4, 0, 0, 0, 0, now.Location())},
+		{"03-04-2022 UTC-8", MDY, time.Date(2022, 3, 4, 0, 0, 0, 0, fixedZone(-8))},
+		{"2022-03-04", MDY, time.Date(2022, 3, 4, 0, 0, 0, 0, now.Location())},
+		{"3/4", DMY, time.Date(2023, 4, 3, 0, 0, 0, 0, now.Location())},
+		{"3/4", MDY, time.Date(2023, 3, 4, 0, 0, 0, 0, now.Location())},
+	}
+	for _, c := range cases {
+		t.Run(c.Input, func(t *testing.T) {
+			v, err := Parse(c.Input, now, WithDateOrder(c.Order))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !v.Equal(c.WantTime) {
+				t.Errorf("got %v, want %v", v, c.WantTime)
+			}
+		})
+	}
+
+	for _, input := range []string{"03/04/2022", "03-04-2022", "03.04.2022", "5pm 03/04/2022"} {
+		t.Run(input+" ambiguous", func(t *testing.T) {
+			v, err := Parse(input, now)
+			if !errors.Is(err, ErrAmbiguousDate) {
+				t.Errorf("got %v, %v, want ErrAmbiguousDate", v, err)
+			}
+		})
+	}
+
+	for _, c := range []struct {
+		Input string
+		Order DateOrder
+	}{
+		{"03/31/2022", DMY},
+		{"31/03/2022", MDY},
+		{"30/02/2022", NoDateOrder},
+		{"2022/03.04", NoDateOrder},
+		{"3/4", NoDateOrder},
+		{"3/31", DMY},
+	} {
+		t.Run(c.Input+" invalid", func(t *testing.T) {
+			v, err := Parse(c.Input, now, WithDateOrder(c.Order))
+			if err == nil {
+				t.Errorf("err is nil, result is %v", v)
+			}
+		})
+	}
+
+	// Syntax errors before an ambiguous date are not blamed on it.
+	for _, input := range []string{"blah 03/04/2022", "tomorrow blah 03/04/2022"} {
+		t.Run(input+" syntax error", func(t *testing.T) {
+			v, err := ParseRange(input, now)
+			if err == nil || errors.Is(err, ErrAmbiguousDate) {
+				t.Errorf("got %v, %v, want a syntax error", v, err)
+			}
+		})
+	}
+}
+
+func TestParseAll(t *testing.T) {
+	type want struct {
+		Time  time.Time
+		Rule  string
+		Score float64
+	}
+	var cases = []struct {
+		Input   string
+		Options []func(o *opts)
+		Want    []want
+	}{
+		{"tomorrow", nil, []want{
+			{time.Date(2022, 9, 30, 0, 0, 0, 0, time.UTC), "", 1},
+		}},
+		{"december", nil, []want{
+			{time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC), "future", 2.0 / 3},
+			{time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC), "past", 1.0 / 3},
+		}},
+		{"may", []func(o *opts){DefaultToPast}, []want{
+			{time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC), "past", 1.0 / 3},
+			{time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), "future", 1.0 / 6},
+		}},
+		{"03/04/2022", nil, []want{
+			{time.Date(2022, 4, 3, 0, 0, 0, 0, time.UTC), "day first", 0.5},
+			{time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC), "month first", 0.5},
+		}},
+		{"03.04.2022", []func(o *opts){WithDateOrder(MDY)}, []want{
+			{time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC), "month first", 2.0 / 3},
+			{time.Date(2022, 4, 3, 0, 0, 0, 0, time.UTC), "day first", 1.0 / 3},
+		}},
+		{"31/03/2022", nil, []want{
+			{time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC), "", 1},
+		}},
+		{"3/4", nil, []want{
+			{time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC), "future, day first", 1.0 / 3},
+			{time.Date(2023, 3, 4, 0, 0, 0, 0, time.UTC), "future, month first", 1.0 / 3},
+			{time.Date(2022, 4, 3, 0, 0, 0, 0, time.UTC), "past, day first", 1.0 / 6},
+			{time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC), "past, month first", 1.0 / 6},
+		}},
+		{"12", nil, []want{
+			{time.Date(2022, 9, 29, 12, 0, 0, 0, time.UTC), "hour", 1.0 / 3},
+			{time.Date(2022, 10, 12, 0, 0, 0, 0, time.UTC), "future, day of the month", 2.0 / 9},
+			{time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC), "future, month", 2.0 / 9},
+			{time.Date(2022, 9, 12, 0, 0, 0, 0, time.UTC), "past, day of the month", 1.0 / 9},
+			{time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC), "past, month", 1.0 / 9},
+		}},
+		{"September 17, 2012 at 5pm CST", nil, []want{
+			{time.Date(2012, 9, 17, 17, 0, 0, 0, fixedZone(-6)), "Central Standard Time", 0.5},
+			{time.Date(2012, 9, 17, 17, 0, 0, 0, fixedZone(8)), "China Standard Time", 0.25},
+			{time.Date(2012, 9, 17, 17, 0, 0, 0, fixedZone(-5)), "Cuba Standard Time", 0.25},
+		}},
+	}
+	for _, c := range cases {
+		t.Run(c.Input, func(t *testing.T) {
+			got, err := ParseAll(c.Input, now, c.Options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(c.Want) {
+				t.Fatalf("got %d interpretations, want %d: %+v", len(got), len(c.Want), got)
+			}
+			for i, w := range c.Want {
+				g := got[i]
+				if !g.Range.Time.Equal(w.Time) || g.Rule != w.Rule || g.Score-w.Score > 1e-9 || w.Score-g.Score > 1e-9 {
+					t.Errorf("interpretation %d is %v %q %v, want %v %q %v", i, g.Range.Time, g.Rule, g.Score, w.Time, w.Rule, w.Score)
+				}
+			}
+		})
+	}
+
+	for _, input := range []string{"hello", "45"} {
+		t.Run(input+" not a date", func(t *testing.T) {
+			got, err := ParseAll(input, now)
+			if err == nil {
+				t.Errorf("err is nil, result is %+v", got)
+			}
+		})
+	}
+}
+
+func TestParseRange_quarters(t *testing.T) {
+	months := func(y int, m time.Month, n int, g Granularity) Range {
+		s := time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+		return Range{s, s.AddDate(0, n, 0).Add(-time.Second).Sub(s), g}
+	}
+	var cases = []struct {
+		Input   string
+		Options []func(o *opts)
+		Want    Range
+	}{
+		{"Q3 2022", nil, months(2022, 7, 3, Quarter)},
+		{"q4 2021", nil, months(2021, 10, 3, Quarter)},
+		{"2023 Q1", nil, months(2023, 1, 3, Quarter)},
+		{"Q4", nil, months(2022, 10, 3, Quarter)},
+		{"Q3", nil, months(2023, 7, 3, Quarter)},
+		{"Q3", []func(o *opts){DefaultToPast}, months(2021, 7, 3, Quarter)},
+		{"last quarter", nil, months(2022, 4, 3, Quarter)},
+		{"this quarter", nil, months(2022, 7, 3, Quarter)},
+		{"next quarter", nil, months(2022, 10, 3, Quarter)},
+		{"H1", nil, months(2023, 1, 6, Half)},
+		{"H2 2023", nil, months(2023, 7, 6, Half)},
+		{"FY2024", nil, months(2024, 1, 12, Year)},
+		{"FY24", []func(o *opts){FiscalYearStartsIn(time.October)}, months(2023, 10, 12, Year)},
+		{"FY23 Q2", nil, months(2023, 4, 3, Quarter)},
+		{"FY23 Q2", []func(o *opts){FiscalYearStartsIn(time.July)}, months(2022, 10, 3, Quarter)},
+		{"Q1 FY2023", []func(o *opts){FiscalYearStartsIn(time.April)}, months(2022, 4, 3, Quarter)},
+		{"this FY", []func(o *opts){FiscalYearStartsIn(time.July)}, months(2022, 7, 12, Year)},
+		{"from Q1 to Q2 2023", nil, Range{time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC).Sub(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)), Quarter}},
+	}
+	for _, c := range cases {
+		t.Run(c.Input, func(t *testing.T) {
+			r, err := ParseRange(c.Input, now, c.Options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !r.Time.Equal(c.Want.Time) || r.Duration != c.Want.Duration || r.Granularity != c.Want.Granularity {
+				t.Errorf("got %v %v, want %v %v", r, r.Granularity, c.Want, c.Want.Granularity)
+			}
+		})
+	}
+}
+
+func TestParseRange_weekDates(t *testing.T) {
+	week := func(y int, m time.Month, d int) Range {
+		return truncateWeek(time.Date(y, m, d, 0, 0, 0, 0, time.UTC), time.Monday)
+	}
+	day := func(y int, m time.Month, d int) Range {
+		return truncateDay(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+	}
+	var cases = []struct {
+		Input   string
+		Options []func(o *opts)
+		Want    Range
+	}{
+		{"2022-W05", nil, week(2022, 1, 31)},
+		{"2022W05", nil, week(2022, 1, 31)},
+		{"2022-W05-3", nil, day(2022, 2, 2)},
+		{"2022W05-3", nil, day(2022, 2, 2)},
+		{"2020-W53-7", nil, day(2021, 1, 3)},
+		{"2019-W01-1", nil, day(2018, 12, 31)},
+		{"week 42 2023", nil, week(2023, 10, 16)},
+		{"KW 17", nil, week(2023, 4, 24)},
+		{"kw17", []func(o *opts){DefaultToPast}, week(2022, 4, 25)},
+		{"2022-256", nil, day(2022, 9, 13)},
+		{"2020-366", nil, day(2020, 12, 31)},
+		{"from 2022-W05 to 2022-W07", nil, Range{time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC), 14 * 24 * time.Hour, Week}},
+	}
+	for _, c := range cases {
+		t.Run(c.Input, func(t *testing.T) {
+			r, err := ParseRange(c.Input, now, c.Options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !r.Time.Equal(c.Want.Time) || r.Duration != c.Want.Duration || r.Granularity != c.Want.Granularity {
+				t.Errorf("got %v %v, want %v %v", r, r.Granularity, c.Want, c.Want.Granularity)
+			}
+		})
+	}
+
+	for _, input := range []string{"2022-W53", "2021-W53-1", "2022-W00", "2022-366", "2021-366", "week 54 2022"} {
+		t.Run(input+" invalid", func(t *testing.T) {
+			r, err := ParseRange(input, now)
+			if err == nil {
+				t.Errorf("err is nil, result is %v", r)
+			}
+		})
+	}
+}
+
+func TestRange_formatWeekDates(t *testing.T) {
+	r := truncateDay(time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC))
+	if got, want := r.FormatISOWeek(), "2020-W53"; got != want {
+		t.Errorf("FormatISOWeek() = %q, want %q", got, want)
+	}
+	if got, want := r.FormatISOWeekDate(), "2020-W53-7"; got != want {
+		t.Errorf("FormatISOWeekDate() = %q, want %q", got, want)
+	}
+	if got, want := r.FormatOrdinalDate(), "2021-003"; got != want {
+		t.Errorf("FormatOrdinalDate() = %q, want %q", got, want)
+	}
+}
+
 func TestRange_String(t *testing.T) {
 	type fields struct {
 		Time     time.Time
@@ -1400,3 +1885,291 @@ For he cannot read his tombstone when he's dead.`
 		}
 	}
 }
+
+func TestParseRange_ordinalDays(t *testing.T) {
+	day := func(y int, m time.Month, d int) Range {
+		return truncateDay(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+	}
+	var cases = []struct {
+		Input   string
+		Options []func(o *opts)
+		Want    Range
+	}{
+		{"the second tuesday in november", nil, day(2022, 11, 8)},
+		{"first monday of march 2023", nil, day(2023, 3, 6)},
+		{"1st monday of march", []func(o *opts){DefaultToPast}, day(2022, 3, 7)},
+		{"the 3rd friday of next month", nil, day(2022, 10, 21)},
+		{"last friday of the month", nil, day(2022, 9, 30)},
+		{"last business day of the quarter", nil, day(2022, 9, 30)},
+		{"first business day of next quarter", nil, day(2022, 10, 3)},
+		{"last weekday of december 2022", nil, day(2022, 12, 30)},
+		{"second to last friday of the year", nil, day(2022, 12, 23)},
+		{"second-to-last day of february 2024", nil, day(2024, 2, 28)},
+		{"fifth monday of october 2022", nil, day(2022, 10, 31)},
+		{"first sunday of the week", []func(o *opts){WeekStartsOn(time.Monday)}, day(2022, 10, 2)},
+		{"last day of q1 2023", nil, day(2023, 3, 31)},
+		{"first monday of fy23", []func(o *opts){FiscalYearStartsIn(time.October)}, day(2022, 10, 3)},
+		{"last friday", nil, day(2022, 9, 23)},
+	}
+	for _, c := range cases {
+		t.Run(c.Input, func(t *testing.T) {
+			r, err := ParseRange(c.Input, now, c.Options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !r.Time.Equal(c.Want.Time) || r.Duration != c.Want.Duration || r.Granularity != c.Want.Granularity {
+				t.Errorf("got %v %v, want %v %v", r, r.Granularity, c.Want, c.Want.Granularity)
+			}
+		})
+	}
+
+	for _, input := range []string{"fifth monday of february 2022", "the fifth friday in june 2022"} {
+		t.Run(input+" invalid", func(t *testing.T) {
+			r, err := ParseRange(input, now)
+			if !errors.Is(err, ErrNoSuchDay) {
+				t.Errorf("got %v, %v, want ErrNoSuchDay", r, err)
+			}
+			if v, err := Parse(input, now); !errors.Is(err, ErrNoSuchDay) {
+				t.Errorf("Parse: got %v, %v, want ErrNoSuchDay", v, err)
+			}
+		})
+	}
+}
+
+func TestParseRange_anchors(t *testing.T) {
+	day := func(y int, m time.Month, d int) Range {
+		return truncateDay(time.Date(y, m, d, 0, 0, 0, 0, time.UTC))
+	}
+	days := func(y int, m time.Month, d, n int) Range {
+		return Range{time.Date(y, m, d, 0, 0, 0, 0, time.UTC), time.Duration(n)*24*time.Hour - time.Second, Day}
+	}
+	hour := func(y int, m time.Month, d, h int) Range {
+		return Range{time.Date(y, m, d, h, 0, 0, 0, time.UTC), time.Hour - time.Second, Hour}
+	}
+	halves := PeriodPortions{Early: Portion{0, 0.5}, Mid: Portion{0.25, 0.75}, Late: Portion{0.5, 1}}
+	var cases = []struct {
+		Input   string
+		Options []func(o *opts)
+		Want    Range
+	}{
+		{"end of this month", nil, day(2022, 9, 30)},
+		{"the end of the month", nil, day(2022, 9, 30)},
+		{"end of month", nil, day(2022, 9, 30)},
+		{"start of next week", nil, day(2022, 10, 2)},
+		{"start of next week", []func(o *opts){WeekStartsOn(time.Monday)}, day(2022, 10, 3)},
+		{"beginning of next year", nil, day(2023, 1, 1)},
+		{"beginning of q3", nil, day(2023, 7, 1)},
+		{"end of q3 2022", nil, day(2022, 9, 30)},
+		{"mid-march", nil, days(2023, 3, 11, 11)},
+		{"mid march", nil, days(2023, 3, 11, 11)},
+		{"the middle of march", nil, days(2023, 3, 11, 11)},
+		{"mid-2023", nil, days(2023, 5, 3, 121)},
+		{"early march", nil, days(2023, 3, 1, 10)},
+		{"early in march", []func(o *opts){DefaultToPast}, days(2022, 3, 1, 10)},
+		{"late march", nil, days(2023, 3, 22, 10)},
+		{"late last year", nil, days(2021, 9, 1, 122)},
+		{"early march", []func(o *opts){WithPeriodPortions(halves)}, days(2023, 3, 1, 16)},
+		{"mid-march", []func(o *opts){WithPeriodPortions(halves)}, days(2023, 3, 9, 15)},
+		{"late march", []func(o *opts){WithPeriodPortions(halves)}, days(2023, 3, 17, 15)},
+		{"EOD", nil, hour(2022, 9, 29, 17)},
+		{"EOD friday", nil, hour(2022, 9, 30, 17)},
+		{"COB tomorrow", nil, hour(2022, 9, 30, 17)},
+		{"close of business on monday", nil, hour(2022, 10, 3, 17)},
+		{"end of day", []func(o *opts){WithEndOfDayHour(18)}, hour(2022, 9, 29, 18)},
+		{"end of the day march 3 2023", nil, hour(2023, 3, 3, 17)},
+	}
+	for _, c := range cases {
+		t.Run(c.Input, func(t *testing.T) {
+			r, err := ParseRange(c.Input, now, c.Options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !r.Time.Equal(c.Want.Time) || r.Duration != c.Want.Duration || r.Granularity != c.Want.Granularity {
+				t.Errorf("got %v %v, want %v %v", r, r.Granularity, c.Want, c.Want.Granularity)
+			}
+		})
+	}
+}
+
+func TestParseRange_dayParts(t *testing.T) {
+	hours := func(m time.Month, d, h, n int) Range {
+		return Range{time.Date(2022, m, d, h, 0, 0, 0, time.UTC), time.Duration(n)*time.Hour - time.Second, Hour}
+	}
+	var cases = []struct {
+		Input   string
+		Options []func(o *opts)
+		Want    Range
+	}{
+		{"tomorrow morning", nil, hours(9, 30, 6, 6)},
+		{"this afternoon", nil, hours(9, 29, 12, 6)},
+		{"friday evening", nil, hours(9, 30, 18, 4)},
+		{"on friday evening", []func(o *opts){DefaultToPast}, hours(9, 23, 18, 4)},
+		{"tonight", nil, hours(9, 29, 18, 12)},
+		{"last night", nil, hours(9, 28, 18, 12)},
+		{"tomorrow night", nil, hours(9, 30, 18, 12)},
+		{"midnight", nil, hours(9, 30, 0, 1)},
+		{"friday midnight", nil, hours(10, 1, 0, 1)},
+		{"overnight", nil, hours(9, 29, 22, 8)},
+		{"october 3 morning", nil, hours(10, 3, 6, 6)},
+		{"tomorrow morning", []func(o *opts){WithDayParts(map[string]DayPart{"morning": {5, 11}})}, hours(9, 30, 5, 6)},
+	}
+	for _, c := range cases {
+		t.Run(c.Input, func(t *testing.T) {
+			r, err := ParseRange(c.Input, now, c.Options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !r.Time.Equal(c.Want.Time) || r.Duration != c.Want.Duration || r.Granularity != c.Want.Granularity {
+				t.Errorf("got %v %v, want %v %v", r, r.Granularity, c.Want, c.Want.Granularity)
+			}
+		})
+	}
+
+	t.Run("no such part of day", func(t *testing.T) {
+		r, err := ParseRange("tonight", now, WithDayParts(map[string]DayPart{"morning": {6, 12}}))
+		if err == nil {
+			t.Errorf("err is nil, result is %v", r)
+		}
+	})
+
+	t.Run("parts copied", func(t *testing.T) {
+		parts := map[string]DayPart{"morning": {5, 11}}
+		option := WithDayParts(parts)
+		parts["morning"] = DayPart{7, 8}
+		r, err := ParseRange("tomorrow morning", now, option)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := time.Date(2022, 9, 30, 5, 0, 0, 0, time.UTC); !r.Time.Equal(want) {
+			t.Errorf("got %v, want %v", r.Time, want)
+		}
+	})
+}
+
+func TestParseOpenRange(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	var cases = []struct {
+		Input      string
+		Options    []func(o *opts)
+		From       time.Time
+		StartBound Bound
+		To         time.Time
+		EndBound   Bound
+	}{
+		{"since march", nil, date(2022, 3, 1), Inclusive, time.Time{}, Unbounded},
+		{"since march", []func(o *opts){DefaultToFuture}, date(2022, 3, 1), Inclusive, time.Time{}, Unbounded},
+		{"as of monday", nil, date(2022, 9, 26), Inclusive, time.Time{}, Unbounded},
+		{"after next week", nil, date(2022, 10, 2), Exclusive, time.Time{}, Unbounded},
+		{"before 2020 AD", nil, time.Time{}, Unbounded, date(2020, 1, 1), Exclusive},
+		{"until friday", nil, time.Time{}, Unbounded, date(2022, 9, 30), Inclusive},
+		{"until friday", []func(o *opts){DefaultToPast}, time.Time{}, Unbounded, date(2022, 9, 30), Inclusive},
+		{"by the end of the month", nil, time.Time{}, Unbounded, date(2022, 9, 30), Inclusive},
+		{"up to march 3 2022", nil, time.Time{}, Unbounded, date(2022, 3, 3), Inclusive},
+		{"after 2019 AD and before 2022 AD", nil, date(2019, 1, 1), Exclusive, date(2022, 1, 1), Exclusive},
+		{"since march until june", nil, date(2022, 3, 1), Inclusive, date(2022, 6, 1), Inclusive},
+		{"since monday until tomorrow", nil, date(2022, 9, 26), Inclusive, date(2022, 9, 30), Inclusive},
+		{"from march 3 2022 to march 5 2022", nil, date(2022, 3, 3), Inclusive, date(2022, 3, 3), Inclusive},
+	}
+	for _, c := range cases {
+		t.Run(c.Input, func(t *testing.T) {
+			r, err := ParseOpenRange(c.Input, now, c.Options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.StartBound != c.StartBound || (c.StartBound != Unbounded && !r.From.Time.Equal(c.From)) {
+				t.Errorf("start is %v %v, want %v %v", r.From, r.StartBound, c.From, c.StartBound)
+			}
+			if r.EndBound != c.EndBound || (c.EndBound != Unbounded && !r.To.Time.Equal(c.To)) {
+				t.Errorf("end is %v %v, want %v %v", r.To, r.EndBound, c.To, c.EndBound)
+			}
+		})
+	}
+
+	for _, input := range []string{"since", "before the flood"} {
+		t.Run(input+" invalid", func(t *testing.T) {
+			r, err := ParseOpenRange(input, now)
+			if err == nil {
+				t.Errorf("err is nil, result is %+v", r)
+			}
+		})
+	}
+}
+
+func TestOpenRange_contains(t *testing.T) {
+	march := truncateMonth(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	april := time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC)
+	var cases = []struct {
+		Name string
+		R    OpenRange
+		T    time.Time
+		Want bool
+	}{
+		{"all time", OpenRange{}, time.Time{}, true},
+		{"since march, in march", OpenRange{From: march, StartBound: Inclusive}, march.Time, true},
+		{"after march, in march", OpenRange{From: march, StartBound: Exclusive}, march.Time, false},
+		{"after march, in april", OpenRange{From: march, StartBound: Exclusive}, april, true},
+		{"before march, in march", OpenRange{To: march, EndBound: Exclusive}, march.Time, false},
+		{"until march, in march", OpenRange{To: march, EndBound: Inclusive}, march.End(), true},
+		{"until march, in april", OpenRange{To: march, EndBound: Inclusive}, april, false},
+		{"open march", march.Open(), march.Time, true},
+	}
+	for _, c := range cases {
+		t.Run(c.Name, func(t *testing.T) {
+			if got := c.R.Contains(c.T); got != c.Want {
+				t.Errorf("Contains(%v) = %v, want %v", c.T, got, c.Want)
+			}
+		})
+	}
+}
+
+func TestParseRange_sharedRanges(t *testing.T) {
+	date := func(y int, m time.Month, d, h int) time.Time {
+		return time.Date(y, m, d, h, 0, 0, 0, time.UTC)
+	}
+	var cases = []struct {
+		Input string
+		Want  Range
+	}{
+		{"March 3-5, 2022", rangeWithGranularity(date(2022, 3, 3, 0), date(2022, 3, 5, 0), Day)},
+		{"March 3–5, 2022", rangeWithGranularity(date(2022, 3, 3, 0), date(2022, 3, 5, 0), Day)},
+		{"May 3 to 7", rangeWithGranularity(date(2023, 5, 3, 0), date(2023, 5, 7, 0), Day)},
+		{"from May 3 to 7", rangeWithGranularity(date(2023, 5, 3, 0), date(2023, 5, 7, 0), Day)},
+		{"march 3 to april 5 2022", rangeWithGranularity(date(2022, 3, 3, 0), date(2022, 4, 5, 0), Day)},
+		{"Mon-Fri next week", rangeWithGranularity(date(2022, 10, 3, 0), date(2022, 10, 7, 0), Day)},
+		{"mon — fri next week", rangeWithGranularity(date(2022, 10, 3, 0), date(2022, 10, 7, 0), Day)},
+		{"9am-5pm tomorrow", rangeWithGranularity(date(2022, 9, 30, 9), date(2022, 9, 30, 17), Hour)},
+		{"9-11am tomorrow", rangeWithGranularity(date(2022, 9, 30, 9), date(2022, 9, 30, 11), Hour)},
+		{"9 to 5 on tuesday", rangeWithGranularity(date(2022, 10, 4, 9), date(2022, 10, 4, 17), Hour)},
+		{"2019–2021", rangeWithGranularity(date(2019, 1, 1, 0), date(2021, 1, 1, 0), Year)},
+		{"2019 - 2021 AD", rangeWithGranularity(date(2019, 1, 1, 0), date(2021, 1, 1, 0), Year)},
+		{"3 feb 2022 – 6 oct 2022", rangeWithGranularity(date(2022, 2, 3, 0), date(2022, 10, 6, 0), Day)},
+		{"3rd to 5th of may", rangeWithGranularity(date(2023, 5, 3, 0), date(2023, 5, 5, 0), Day)},
+		{"3rd to 5th of may 2022", rangeWithGranularity(date(2022, 5, 3, 0), date(2022, 5, 5, 0), Day)},
+		{"from the 3rd to the 5th of may", rangeWithGranularity(date(2023, 5, 3, 0), date(2023, 5, 5, 0), Day)},
+		{"3rd-5th of May", rangeWithGranularity(date(2023, 5, 3, 0), date(2023, 5, 5, 0), Day)},
+
+		// Not shared
+		{"today to tomorrow at 5pm", rangeWithGranularity(date(2022, 9, 29, 0), date(2022, 9, 30, 17), Hour)},
+		{"second to last friday of the year", Range{date(2022, 12, 23, 0), 24*time.Hour - time.Second, Day}},
+	}
+	for _, c := range cases {
+		t.Run(c.Input, func(t *testing.T) {
+			r, err := ParseRange(c.Input, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !r.Time.Equal(c.Want.Time) || r.Duration != c.Want.Duration || r.Granularity != c.Want.Granularity {
+				t.Errorf("got %v %v, want %v %v", r, r.Granularity, c.Want, c.Want.Granularity)
+			}
+		})
+	}
+
+	t.Run("bare hours with no day", func(t *testing.T) {
+		r, err := ParseRange("9 to 5", now)
+		if err == nil {
+			t.Errorf("err is nil, result is %v", r)
+		}
+	})
+}
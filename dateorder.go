@@ -0,0 +1,169 @@
+package anytime
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+
+	gp "github.com/ijt/goparsify"
+)
+
+// ErrAmbiguousDate is returned by Parse and ParseRange when a numeric date
+// such as "03/04/2022" could be read either day first or month first and the
+// WithDateOrder option was not given to say which.
+var ErrAmbiguousDate = errors.New("ambiguous numeric date")
+
+// errNoNumericDateFound is returned by readNumericDate when the submatches do
+// not make a valid date in the given order.
+var errNoNumericDateFound = errors.New("no numeric date found")
+
+// DateOrder is the order in which the day, month and year of numeric dates
+// like "03/04/2022" are written.
+type DateOrder int
+
+const (
+	// NoDateOrder reads numeric dates whichever way makes them valid, as in
+	// "31/03/2022" and "03/31/2022", and rejects those like "03/04/2022"
+	// that are valid either way. This is the default.
+	NoDateOrder DateOrder = iota
+
+	// DMY reads numeric dates day first, as in "03/04/2022" for 3 April.
+	DMY
+
+	// MDY reads numeric dates month first, as in "03/04/2022" for March 4.
+	MDY
+
+	// YMD reads numeric dates year first, as in "2022-04-03". Dates with the
+	// year last are read as they are with NoDateOrder.
+	YMD
+)
+
+// WithDateOrder sets the option to read numeric dates like "03/04/2022" in
+// the given order.
+func WithDateOrder(order DateOrder) func(o *opts) {
+	return func(o *opts) {
+		o.dateOrder = order
+	}
+}
+
+// numericDatePattern matches numeric dates with the year first or last,
+// separated by slashes, dashes or dots, as in "2022-04-03", "03/04/2022" or
+// "03.04.2022".
+const numericDatePattern = `(?:(\d{4})([-/.])(\d{1,2})([-/.])(\d{1,2})|(\d{1,2})([-/.])(\d{1,2})([-/.])(\d{4}))\b`
+
+var numericDateRx = regexp.MustCompile(`^` + numericDatePattern)
+
+// yearlessDateRx matches numeric dates with no year, separated by a slash, as
+// in "3/4".
+var yearlessDateRx = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})\b`)
+
+// numericDate returns a parser of numeric dates relative to ref, read in the
+// order given by o. Dates with no year, as in "3/4", are only read if the
+// order is DMY or MDY, since things like "24/7" and "1/2" are seldom dates,
+// and are the next or last such date as the default direction says.
+func numericDate(ref time.Time, o opts) gp.Parser {
+	return func(ps *gp.State, node *gp.Result) {
+		ps.WS(ps)
+		m := numericDateRx.FindStringSubmatch(ps.Get())
+		if m == nil {
+			yearlessDate(ref, o)(ps, node)
+			return
+		}
+		y, mo, d, err := readNumericDate(m, o.dateOrder)
+		if err == ErrAmbiguousDate {
+			o.reject(err)
+		}
+		if err != nil {
+			ps.ErrorHere("unambiguous numeric date")
+			return
+		}
+		node.Token = m[0]
+		node.Result = Range{
+			time.Date(y, mo, d, 0, 0, 0, 0, ref.Location()),
+			24*time.Hour - time.Second,
+			Day,
+		}
+		ps.Advance(len(m[0]))
+	}
+}
+
+// yearlessDate is the part of numericDate that parses dates with no year.
+func yearlessDate(ref time.Time, o opts) gp.Parser {
+	return func(ps *gp.State, node *gp.Result) {
+		m := yearlessDateRx.FindStringSubmatch(ps.Get())
+		if m == nil || (o.dateOrder != DMY && o.dateOrder != MDY) {
+			ps.ErrorHere("numeric date")
+			return
+		}
+		mo, _ := strconv.Atoi(m[1])
+		d, _ := strconv.Atoi(m[2])
+		if o.dateOrder == DMY {
+			mo, d = d, mo
+		}
+		// Leap years have every day that any year has.
+		if !okDate(2000, mo, d) {
+			ps.ErrorHere("numeric date")
+			return
+		}
+		r := nextMonth(ref, time.Month(mo))
+		if o.defaultDirection == past {
+			r = prevMonth(ref, time.Month(mo))
+		}
+		node.Token = m[0]
+		node.Result = truncateDay(time.Date(r.Year(), r.Month(), d, 0, 0, 0, 0, ref.Location()))
+		ps.Advance(len(m[0]))
+	}
+}
+
+// readNumericDate returns the date given by a submatch m of numericDateRx,
+// read in the given order. It returns ErrAmbiguousDate if order does not say
+// how to read it.
+func readNumericDate(m []string, order DateOrder) (int, time.Month, int, error) {
+	if m[1] != "" {
+		// YYYY/MM/DD
+		if m[2] != m[4] {
+			return 0, 0, 0, errNoNumericDateFound
+		}
+		y, _ := strconv.Atoi(m[1])
+		mo, _ := strconv.Atoi(m[3])
+		d, _ := strconv.Atoi(m[5])
+		if !okDate(y, mo, d) {
+			return 0, 0, 0, errNoNumericDateFound
+		}
+		return y, time.Month(mo), d, nil
+	}
+
+	// DD/MM/YYYY or MM/DD/YYYY
+	if m[7] != m[9] {
+		return 0, 0, 0, errNoNumericDateFound
+	}
+	a, _ := strconv.Atoi(m[6])
+	b, _ := strconv.Atoi(m[8])
+	y, _ := strconv.Atoi(m[10])
+	okDMY := okDate(y, b, a)
+	okMDY := okDate(y, a, b)
+	switch order {
+	case DMY:
+		okMDY = false
+	case MDY:
+		okDMY = false
+	}
+	switch {
+	case okDMY && okMDY && a != b:
+		return 0, 0, 0, ErrAmbiguousDate
+	case okDMY:
+		return y, time.Month(b), a, nil
+	case okMDY:
+		return y, time.Month(a), b, nil
+	}
+	return 0, 0, 0, errNoNumericDateFound
+}
+
+// okDate returns whether there is a day d in month m of year y.
+func okDate(y, m, d int) bool {
+	if m < 1 || m > 12 || d < 1 {
+		return false
+	}
+	return d <= time.Date(y, time.Month(m)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
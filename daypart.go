@@ -0,0 +1,106 @@
+package anytime
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	gp "github.com/ijt/goparsify"
+)
+
+// DayPart is a part of a day running from the hour From to the hour To, with
+// hours past 24 running into the next day, so that {22, 30} is from 10pm to
+// 6am the next morning.
+type DayPart struct {
+	From, To int
+}
+
+// defaultDayParts returns the parts of a day meant by words like "morning"
+// by default, as in "tomorrow morning", "friday evening" or "tonight".
+func defaultDayParts() map[string]DayPart {
+	return map[string]DayPart{
+		"morning":   {6, 12},
+		"afternoon": {12, 18},
+		"evening":   {18, 22},
+		"night":     {18, 30},
+		"overnight": {22, 30},
+		"midnight":  {24, 25},
+	}
+}
+
+// WithDayParts sets the option to take the names of parts of a day, such as
+// "morning" in "tomorrow morning", to mean the hours given by parts rather
+// than the usual ones, such as 6am to noon for "morning". The names must be
+// in lower case. Changing parts afterwards does not change the option.
+func WithDayParts(parts map[string]DayPart) func(o *opts) {
+	copied := make(map[string]DayPart, len(parts))
+	for name, p := range parts {
+		copied[name] = p
+	}
+	return func(o *opts) {
+		o.dayParts = copied
+	}
+}
+
+// on returns the range of the part p of the day starting range d.
+func (p DayPart) on(d Range) Range {
+	y, m, dom := d.Date()
+	s := time.Date(y, m, dom, p.From, 0, 0, 0, d.Location())
+	e := time.Date(y, m, dom, p.To, 0, 0, 0, d.Location()).Add(-time.Second)
+	return Range{s, e.Sub(s), Hour}
+}
+
+// dayPart returns a parser of the names of the given parts of a day,
+// resulting in the DayPart.
+func dayPart(parts map[string]DayPart) gp.Parser {
+	var names []string
+	for name := range parts {
+		names = append(names, regexp.QuoteMeta(name))
+	}
+	if len(names) == 0 {
+		return func(ps *gp.State, node *gp.Result) {
+			ps.ErrorHere("part of a day")
+		}
+	}
+	// Longest first, so that "overnight" is not read as "over".
+	sort.Slice(names, func(i, j int) bool { return len(names[i]) > len(names[j]) })
+	rx := `(?i)(` + strings.Join(names, "|") + `)\b`
+	return gp.NamedRegex("part of a day", rx).Map(func(n *gp.Result) {
+		n.Result = parts[strings.ToLower(n.Token)]
+	})
+}
+
+// dayPartAlone returns a parser of parts of a day that are not attached to a
+// date: "tonight", "last night", "midnight" and "overnight", meaning the night
+// just gone in the case of "last night" and the day of ref otherwise, or
+// "this" followed by the name of any of the parts, as in "this afternoon".
+func dayPartAlone(ref time.Time, parts map[string]DayPart) gp.Parser {
+	today := truncateDay(ref)
+	named := func(name string, d Range) gp.Parser {
+		return func(ps *gp.State, node *gp.Result) {
+			p, ok := parts[name]
+			if !ok {
+				ps.ErrorHere(name)
+				return
+			}
+			node.Result = p.on(d)
+		}
+	}
+	tonight := gp.Seq(I("tonight"), named("night", today)).Map(func(n *gp.Result) {
+		n.Result = n.Child[1].Result
+	})
+	lastNight := gp.Seq(I("last"), I("night"), named("night", truncateDay(ref.AddDate(0, 0, -1)))).Map(func(n *gp.Result) {
+		n.Result = n.Child[2].Result
+	})
+	midnight := gp.Seq(I("midnight"), named("midnight", today)).Map(func(n *gp.Result) {
+		n.Result = n.Child[1].Result
+	})
+	overnight := gp.Seq(I("overnight"), named("overnight", today)).Map(func(n *gp.Result) {
+		n.Result = n.Child[1].Result
+	})
+	thisPart := gp.Seq(I("this"), dayPart(parts)).Map(func(n *gp.Result) {
+		n.Result = n.Child[1].Result.(DayPart).on(today)
+	})
+	return gp.AnyWithName("part of a day", tonight, lastNight, midnight, overnight, thisPart)
+}
@@ -0,0 +1,82 @@
+package anytime
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FiscalYearStartsIn sets the option to start fiscal years, as in "FY2024",
+// in the month m rather than in January.
+func FiscalYearStartsIn(m time.Month) func(o *opts) {
+	return func(o *opts) {
+		o.fiscalYearStart = m
+	}
+}
+
+// period is a quarter or a half of a year.
+type period struct {
+	// n is the number of the period within the year, starting from 1.
+	n int
+
+	// months is the length of the period in months.
+	months int
+}
+
+// parsePeriod parses a quarter or half of a year such as "Q3" or "h1".
+func parsePeriod(s string) period {
+	n, err := strconv.Atoi(s[1:])
+	if err != nil {
+		panic("parsing quarter or half: " + err.Error())
+	}
+	if strings.EqualFold(s[:1], "h") {
+		return period{n, 6}
+	}
+	return period{n, 3}
+}
+
+// granularity returns the granularity of the period.
+func (p period) granularity() Granularity {
+	if p.months == 6 {
+		return Half
+	}
+	return Quarter
+}
+
+// in returns the range of the period within the year starting at the time
+// yearStart.
+func (p period) in(yearStart time.Time) Range {
+	s := yearStart.AddDate(0, p.months*(p.n-1), 0)
+	e := s.AddDate(0, p.months, 0).Add(-time.Second)
+	return Range{s, e.Sub(s), p.granularity()}
+}
+
+// next returns the next instance of the period after the one containing ref.
+func (p period) next(ref time.Time) Range {
+	r := p.in(time.Date(ref.Year(), 1, 1, 0, 0, 0, 0, ref.Location()))
+	if r.After(ref) {
+		return r
+	}
+	return p.in(time.Date(ref.Year()+1, 1, 1, 0, 0, 0, 0, ref.Location()))
+}
+
+// prev returns the previous instance of the period before the one containing
+// ref.
+func (p period) prev(ref time.Time) Range {
+	r := p.in(time.Date(ref.Year(), 1, 1, 0, 0, 0, 0, ref.Location()))
+	if r.End().Before(ref) {
+		return r
+	}
+	return p.in(time.Date(ref.Year()-1, 1, 1, 0, 0, 0, 0, ref.Location()))
+}
+
+// startOfFiscalYear returns the start of the fiscal year named y, with fiscal
+// years starting in the month first. Fiscal years are named after the
+// calendar year in which they end, so with fiscal years starting in October,
+// FY2024 runs from October 2023 to September 2024.
+func startOfFiscalYear(y int, first time.Month, loc *time.Location) time.Time {
+	if first != time.January {
+		y--
+	}
+	return time.Date(y, first, 1, 0, 0, 0, 0, loc)
+}
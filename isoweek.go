@@ -0,0 +1,143 @@
+package anytime
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	gp "github.com/ijt/goparsify"
+)
+
+// isoWeekRx matches ISO 8601 week dates such as "2022-W05", "2022W05",
+// "2022-W05-3" and "2022W053".
+var isoWeekRx = regexp.MustCompile(`^(?i)(\d{4})-?W(\d{2})(?:-?([1-7]))?\b`)
+
+// ordinalDateRx matches ISO 8601 ordinal dates such as "2022-256".
+var ordinalDateRx = regexp.MustCompile(`^(\d{4})-(\d{3})\b`)
+
+// weekNumberRx matches week numbers such as "week 42", "week 42 2023",
+// "KW 17" and "kw17".
+var weekNumberRx = regexp.MustCompile(`^(?i)(?:week|wk|kw)\s*(\d{1,2})\b(?:\s+(\d{4})\b)?`)
+
+// isoWeekDate returns a parser of ISO 8601 week dates and ordinal dates in
+// the location of ref, giving weeks for week dates without a day and days
+// otherwise.
+func isoWeekDate(ref time.Time) gp.Parser {
+	return func(ps *gp.State, node *gp.Result) {
+		ps.WS(ps)
+		if m := isoWeekRx.FindStringSubmatch(ps.Get()); m != nil {
+			y, _ := strconv.Atoi(m[1])
+			w, _ := strconv.Atoi(m[2])
+			r, ok := isoWeek(y, w, ref.Location())
+			if !ok {
+				ps.ErrorHere("ISO week date")
+				return
+			}
+			if m[3] != "" {
+				d, _ := strconv.Atoi(m[3])
+				r = truncateDay(r.AddDate(0, 0, d-1))
+			}
+			node.Token = m[0]
+			node.Result = r
+			ps.Advance(len(m[0]))
+			return
+		}
+		if m := ordinalDateRx.FindStringSubmatch(ps.Get()); m != nil {
+			y, _ := strconv.Atoi(m[1])
+			d, _ := strconv.Atoi(m[2])
+			jan1 := time.Date(y, 1, 1, 0, 0, 0, 0, ref.Location())
+			if d < 1 || d > jan1.AddDate(1, 0, -1).YearDay() {
+				ps.ErrorHere("ordinal date")
+				return
+			}
+			node.Token = m[0]
+			node.Result = truncateDay(jan1.AddDate(0, 0, d-1))
+			ps.Advance(len(m[0]))
+			return
+		}
+		ps.ErrorHere("ISO week date or ordinal date")
+	}
+}
+
+// weekNumber returns a parser of week numbers like "week 42 2023" or "KW 17",
+// relative to ref. Week numbers without a year are taken to be the next or
+// the last such week, depending on dir.
+func weekNumber(ref time.Time, dir direction) gp.Parser {
+	return func(ps *gp.State, node *gp.Result) {
+		ps.WS(ps)
+		m := weekNumberRx.FindStringSubmatch(ps.Get())
+		if m == nil {
+			ps.ErrorHere("week number")
+			return
+		}
+		w, _ := strconv.Atoi(m[1])
+		var r Range
+		var ok bool
+		if m[2] != "" {
+			y, _ := strconv.Atoi(m[2])
+			r, ok = isoWeek(y, w, ref.Location())
+		} else {
+			y, cw := ref.ISOWeek()
+			cur, _ := isoWeek(y, cw, ref.Location())
+			r, ok = isoWeek(y, w, ref.Location())
+			switch {
+			case dir == future && (!ok || !r.After(cur.Time)):
+				r, ok = isoWeek(y+1, w, ref.Location())
+			case dir == past && (!ok || !r.Before(cur.Time)):
+				r, ok = isoWeek(y-1, w, ref.Location())
+			}
+		}
+		if !ok {
+			ps.ErrorHere("week number")
+			return
+		}
+		node.Token = m[0]
+		node.Result = r
+		ps.Advance(len(m[0]))
+	}
+}
+
+// isoWeekStart returns the Monday that starts week w of the ISO 8601
+// week-numbering year y, in loc. Week 1 is the week containing January 4.
+func isoWeekStart(y, w int, loc *time.Location) time.Time {
+	jan4 := time.Date(y, 1, 4, 0, 0, 0, 0, loc)
+	sinceMonday := (int(jan4.Weekday()) + 6) % 7
+	return jan4.AddDate(0, 0, 7*(w-1)-sinceMonday)
+}
+
+// isoWeeksIn returns the number of weeks, 52 or 53, in the ISO 8601
+// week-numbering year y.
+func isoWeeksIn(y int) int {
+	_, w := time.Date(y, 12, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return w
+}
+
+// isoWeek returns the range of week w of the ISO 8601 week-numbering year y,
+// or false if there is no such week.
+func isoWeek(y, w int, loc *time.Location) (Range, bool) {
+	if w < 1 || w > isoWeeksIn(y) {
+		return Range{}, false
+	}
+	return truncateWeek(isoWeekStart(y, w, loc), time.Monday), true
+}
+
+// FormatISOWeek returns the ISO 8601 week containing the start of r, as in
+// "2022-W05".
+func (r Range) FormatISOWeek() string {
+	y, w := r.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", y, w)
+}
+
+// FormatISOWeekDate returns the start of r as an ISO 8601 week date, as in
+// "2022-W05-3" for the Wednesday of week 5 of 2022.
+func (r Range) FormatISOWeekDate() string {
+	wd := (int(r.Weekday())+6)%7 + 1
+	return fmt.Sprintf("%s-%d", r.FormatISOWeek(), wd)
+}
+
+// FormatOrdinalDate returns the start of r as an ISO 8601 ordinal date, as in
+// "2022-256".
+func (r Range) FormatOrdinalDate() string {
+	return fmt.Sprintf("%04d-%03d", r.Year(), r.YearDay())
+}
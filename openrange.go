@@ -0,0 +1,168 @@
+package anytime
+
+import (
+	"fmt"
+	"time"
+
+	gp "github.com/ijt/goparsify"
+)
+
+// Bound is how an OpenRange is bounded at one of its ends.
+type Bound int
+
+const (
+	// Unbounded means that the range goes on forever at that end.
+	Unbounded Bound = iota
+
+	// Inclusive means that the range includes the range bounding it at that
+	// end, as "since march" includes March and "until friday" includes
+	// Friday.
+	Inclusive
+
+	// Exclusive means that the range stops short of the range bounding it at
+	// that end, as "after march" starts on April 1 and "before 2020" ends
+	// when 2019 does.
+	Exclusive
+)
+
+// OpenRange is a range of times that may have no start or no end, as in
+// "since march" or "before 2020". The zero value is unbounded at both ends
+// and so contains all times.
+type OpenRange struct {
+	// From is the range bounding the start, unless StartBound is Unbounded.
+	From       Range
+	StartBound Bound
+
+	// To is the range bounding the end, unless EndBound is Unbounded.
+	To       Range
+	EndBound Bound
+}
+
+// Open returns r as an OpenRange bounded inclusively by r at both ends.
+func (r Range) Open() OpenRange {
+	return OpenRange{r, Inclusive, r, Inclusive}
+}
+
+// Contains reports whether t is within r.
+func (r OpenRange) Contains(t time.Time) bool {
+	switch {
+	case r.StartBound == Inclusive && t.Before(r.From.Time):
+		return false
+	case r.StartBound == Exclusive && !t.After(r.From.End()):
+		return false
+	case r.EndBound == Inclusive && t.After(r.To.End()):
+		return false
+	case r.EndBound == Exclusive && !t.Before(r.To.Time):
+		return false
+	}
+	return true
+}
+
+// ParseOpenRange is like ParseRange but also accepts ranges with no start or
+// no end, as described for OpenRangeParser.
+func ParseOpenRange(s string, ref time.Time, opts ...func(o *opts)) (OpenRange, error) {
+	var rejection error
+	p := OpenRangeParser(ref, withOptions(opts, noteRejection(&rejection))...)
+	result, _, err := gp.Run(p, s, gp.UnicodeWhitespace)
+	if err != nil {
+		return OpenRange{}, fmt.Errorf("running open range parser: %w", rejectionError(rejection, err))
+	}
+	return result.(OpenRange), nil
+}
+
+// OpenRangeParser takes a reference time ref and returns a parser for ranges
+// that may have no start or no end, as in "since march", "as of monday",
+// "after next week", "before 2020", "until friday", "by tomorrow" or "up to
+// 2022", resulting in OpenRanges. A start and an end can be given together,
+// as in "after 2019 and before 2022". The ranges after "since", "as of",
+// "until", "by" and "up to" are included, and those after "after" and
+// "before" are not. Since "since" and "as of" look back and "until", "by" and
+// "up to" look ahead, the ranges after them are read as in the past or the
+// future whatever the default direction. Ranges that RangeParser accepts are
+// given as by Range.Open.
+func OpenRangeParser(ref time.Time, options ...func(o *opts)) gp.Parser {
+	start := openStart(ref, options)
+	end := openEnd(ref, options, nil)
+	bounded := RangeParser(ref, options...).Map(func(n *gp.Result) {
+		n.Result = n.Result.(Range).Open()
+	})
+	return gp.AnyWithName("open range", startAndEnd(ref, options, start), start, end, bounded)
+}
+
+// withOptions returns options followed by extra, without changing options.
+func withOptions(options []func(o *opts), extra ...func(o *opts)) []func(o *opts) {
+	return append(append([]func(o *opts){}, options...), extra...)
+}
+
+// openStart returns a parser of the start of an open range relative to ref,
+// as in "since march" or "after next week".
+func openStart(ref time.Time, options []func(o *opts)) gp.Parser {
+	sinceWords := gp.AnyWithName("since or as of", I("since"), gp.Seq(I("as"), I("of")))
+	since := gp.Seq(sinceWords, Parser(ref, withOptions(options, DefaultToPast)...)).Map(func(n *gp.Result) {
+		n.Result = OpenRange{From: n.Child[1].Result.(Range), StartBound: Inclusive}
+	})
+	after := gp.Seq(I("after"), Parser(ref, options...)).Map(func(n *gp.Result) {
+		n.Result = OpenRange{From: n.Child[1].Result.(Range), StartBound: Exclusive}
+	})
+	return gp.AnyWithName("since or after", since, after)
+}
+
+// openEnd returns a parser of the end of an open range relative to ref, as in
+// "before 2020" or "until friday". If dir is not nil, it sets the direction
+// in which to read the range after "until" and the like instead of the
+// future.
+func openEnd(ref time.Time, options []func(o *opts), dir func(o *opts)) gp.Parser {
+	if dir == nil {
+		dir = DefaultToFuture
+	}
+	before := gp.Seq(I("before"), Parser(ref, options...)).Map(func(n *gp.Result) {
+		n.Result = OpenRange{To: n.Child[1].Result.(Range), EndBound: Exclusive}
+	})
+	untilWords := gp.AnyWithName("until, by or up to", I("until"), I("till"), I("til"), I("by"), gp.Seq(I("up"), I("to")))
+	until := gp.Seq(untilWords, Parser(ref, withOptions(options, dir)...)).Map(func(n *gp.Result) {
+		n.Result = OpenRange{To: n.Child[1].Result.(Range), EndBound: Inclusive}
+	})
+	return gp.AnyWithName("before or until", before, until)
+}
+
+// startAndEnd returns a parser of the start of an open range parsed by start
+// followed by its end, as in "since march until june" or "after 2019 and
+// before 2022". Ends like "june" that depend on the direction are taken to be
+// the first such after the start.
+func startAndEnd(ref time.Time, options []func(o *opts), start gp.Parser) gp.Parser {
+	and := gp.Maybe(I("and"))
+	end := openEnd(ref, options, nil)
+	return func(ps *gp.State, node *gp.Result) {
+		startPos := ps.Pos
+		start(ps, node)
+		if ps.Errored() {
+			return
+		}
+		r := node.Result.(OpenRange)
+		and(ps, node)
+		endPos := ps.Pos
+		end(ps, node)
+		if ps.Errored() {
+			ps.Pos = startPos
+			return
+		}
+		e := node.Result.(OpenRange)
+		eoEnd := ps.Pos
+
+		// Read the end again in the past and, if that gives another range,
+		// forwards from the start.
+		ps.Pos = endPos
+		openEnd(ref, withOptions(options, DefaultToPast), DefaultToPast)(ps, node)
+		if !ps.Errored() && ps.Pos == eoEnd && !node.Result.(OpenRange).To.Time.Equal(e.To.Time) {
+			ps.Pos = endPos
+			openEnd(r.From.Time, withOptions(options, DefaultToFuture), nil)(ps, node)
+			if !ps.Errored() && ps.Pos == eoEnd {
+				e = node.Result.(OpenRange)
+			}
+		}
+		ps.Recover()
+		ps.Pos = eoEnd
+		r.To, r.EndBound = e.To, e.EndBound
+		node.Result = r
+	}
+}
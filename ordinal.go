@@ -0,0 +1,89 @@
+package anytime
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	gp "github.com/ijt/goparsify"
+)
+
+// ErrNoSuchDay is returned by Parse and ParseRange for expressions like
+// "fifth monday of february 2022" that name a day the period does not have.
+var ErrNoSuchDay = errors.New("no such day in the period")
+
+// ordinalWords maps the lower-cased words for ordinals to their values, with
+// negative values counting from the end.
+var ordinalWords = map[string]int{
+	"first":          1,
+	"1st":            1,
+	"second":         2,
+	"2nd":            2,
+	"third":          3,
+	"3rd":            3,
+	"fourth":         4,
+	"4th":            4,
+	"fifth":          5,
+	"5th":            5,
+	"last":           -1,
+	"penultimate":    -2,
+	"second to last": -2,
+	"second-to-last": -2,
+}
+
+// ordinal parses an ordinal such as "third" or "second to last".
+var ordinal = gp.Regex(`(?i)(first|1st|second[- ]to[- ]last|penultimate|second|2nd|third|3rd|fourth|4th|fifth|5th|last)\b`).Map(func(n *gp.Result) {
+	n.Result = ordinalWords[strings.ToLower(n.Token)]
+})
+
+// isBusinessDay reports whether t is on a weekday from Monday to Friday.
+func isBusinessDay(t time.Time) bool {
+	return t.Weekday() != time.Saturday && t.Weekday() != time.Sunday
+}
+
+// ordinalDayOf returns a parser of ordinal days of periods, as in "the
+// second tuesday in november" or "last business day of the quarter", given
+// parsers of the kinds of day, such as "tuesday", resulting in funcs that
+// report whether a time is on that kind of day, and of the periods. It fails
+// with ErrNoSuchDay, noted as o says, if the period has no such day, as with
+// "fifth monday of february 2022".
+func ordinalDayOf(day, period gp.Parser, o opts) gp.Parser {
+	seq := gp.Seq(gp.Maybe(I("the")), ordinal, day, gp.AnyWithName("of or in", I("of"), I("in")), period)
+	return func(ps *gp.State, node *gp.Result) {
+		start := ps.Pos
+		seq(ps, node)
+		if ps.Errored() {
+			return
+		}
+		n := node.Child[1].Result.(int)
+		match := node.Child[2].Result.(func(time.Time) bool)
+		p := node.Child[4].Result.(Range)
+		d, ok := nthDay(p, n, match)
+		if !ok {
+			o.reject(ErrNoSuchDay)
+			ps.Pos = start
+			ps.ErrorHere("a day that the period has")
+			return
+		}
+		node.Result = d
+	}
+}
+
+// nthDay returns the nth day within the range p for which match returns
+// true, counting from the end if n is negative, or false if there is no such
+// day.
+func nthDay(p Range, n int, match func(time.Time) bool) (Range, bool) {
+	var days []time.Time
+	for d := p.Time; !d.After(p.End()); d = d.AddDate(0, 0, 1) {
+		if match(d) {
+			days = append(days, d)
+		}
+	}
+	if n < 0 {
+		n += len(days) + 1
+	}
+	if n < 1 || n > len(days) {
+		return Range{}, false
+	}
+	return truncateDay(days[n-1]), true
+}
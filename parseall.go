@@ -0,0 +1,351 @@
+package anytime
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	gp "github.com/ijt/goparsify"
+)
+
+// Interpretation is one way of reading a string, as returned by ParseAll.
+type Interpretation struct {
+	// Range is the range that the string means when read this way.
+	Range Range
+
+	// Rule says how the string was read to get Range, as in "past",
+	// "month first" or "China Standard Time", with several of them
+	// separated by commas. It is empty if the string can only be read one
+	// way.
+	Rule string
+
+	// Score is the confidence in this interpretation, from 0 to 1. The
+	// scores of the interpretations of a string add up to at most 1, with
+	// the rest being the confidence that it is not a date at all, as with
+	// "may" in "we may go".
+	Score float64
+}
+
+// reading is a choice of how to read strings that could be read more than
+// one way, such as in the future or in the past.
+type reading struct {
+	name   string
+	weight float64
+	apply  func(o *opts)
+}
+
+// bareNumberReading is a way of reading a number on its own, as in "12".
+type bareNumberReading int
+
+const (
+	// noBareNumbers leaves numbers on their own unread.
+	noBareNumbers bareNumberReading = iota
+
+	// bareDay reads them as days of the month.
+	bareDay
+
+	// bareMonth reads them as months.
+	bareMonth
+
+	// bareHour reads them as hours on the 24-hour clock.
+	bareHour
+)
+
+// bareNumberRx matches a number of one or two digits.
+var bareNumberRx = regexp.MustCompile(`^\d{1,2}$`)
+
+// nonDateWords are words that Parse takes to be dates but that, written in
+// lower case, are more often used for something else.
+var nonDateWords = map[string]bool{
+	"may":   true,
+	"march": true,
+	"mar":   true,
+	"sun":   true,
+	"sat":   true,
+	"wed":   true,
+}
+
+// ParseAll is like ParseRange but returns every plausible interpretation of
+// s, most likely first, rather than picking one. It reads strings like
+// "december" both in the future and in the past, numeric dates like
+// "03/04/2022" and "3/4" both day first and month first, numbers on their own
+// like "12" as days of the month, months and hours, and time zones like "CST"
+// as each of the zones they may stand for. The choices given by the options
+// are taken to be more likely than the others.
+func ParseAll(s string, ref time.Time, options ...func(o *opts)) ([]Interpretation, error) {
+	var o opts
+	for _, optFunc := range options {
+		optFunc(&o)
+	}
+	dims := [][]reading{directionReadings(o), dateOrderReadings(o)}
+	if numbers := bareNumberReadings(s); numbers != nil {
+		dims = append(dims, numbers)
+	}
+	if zones := zoneReadings(s, ref, options); zones != nil {
+		dims = append(dims, zones)
+	}
+
+	type group struct {
+		Interpretation
+		weight float64
+		names  []map[string]bool
+	}
+	var groups []*group
+	// seen has the names of the choices in each dimension that gave a
+	// range.
+	seen := make([]map[string]bool, len(dims))
+	for i := range seen {
+		seen[i] = map[string]bool{}
+	}
+	var total float64
+	var firstErr error
+	for _, rs := range combineReadings(dims) {
+		readingOptions := append([]func(o *opts){}, options...)
+		weight := 1.0
+		for _, rd := range rs {
+			readingOptions = append(readingOptions, rd.apply)
+			weight *= rd.weight
+		}
+		r, err := ParseRange(s, ref, readingOptions...)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		total += weight
+		var g *group
+		for _, h := range groups {
+			if h.Range.Equal(r.Time) && h.Range.Duration == r.Duration {
+				g = h
+				break
+			}
+		}
+		if g == nil {
+			g = &group{Interpretation: Interpretation{Range: r}}
+			g.names = make([]map[string]bool, len(dims))
+			for i := range g.names {
+				g.names[i] = map[string]bool{}
+			}
+			groups = append(groups, g)
+		}
+		g.weight += weight
+		for i, rd := range rs {
+			g.names[i][rd.name] = true
+			seen[i][rd.name] = true
+		}
+	}
+	if len(groups) == 0 {
+		return nil, firstErr
+	}
+
+	var is []Interpretation
+	for _, g := range groups {
+		// Only mention the choices that made a difference.
+		var rule []string
+		for i, dim := range dims {
+			if len(g.names[i]) == len(seen[i]) {
+				continue
+			}
+			var names []string
+			for _, rd := range dim {
+				if g.names[i][rd.name] {
+					names = append(names, rd.name)
+				}
+			}
+			rule = append(rule, strings.Join(names, " or "))
+		}
+		g.Rule = strings.Join(rule, ", ")
+		g.Score = g.weight / total
+		if nonDateWords[strings.TrimSpace(s)] {
+			g.Score /= 2
+		}
+		is = append(is, g.Interpretation)
+	}
+	sort.SliceStable(is, func(i, j int) bool {
+		return is[i].Score > is[j].Score
+	})
+	return is, nil
+}
+
+// directionReadings returns the readings of strings that could be in the
+// future or in the past, favoring the default direction in o.
+func directionReadings(o opts) []reading {
+	toFuture := reading{"future", 1, DefaultToFuture}
+	toPast := reading{"past", 1, DefaultToPast}
+	if o.defaultDirection == past {
+		toPast.weight = 2
+		return []reading{toPast, toFuture}
+	}
+	toFuture.weight = 2
+	return []reading{toFuture, toPast}
+}
+
+// dateOrderReadings returns the readings of numeric dates that could be day
+// or month first, favoring o.dateOrder if it is DMY or MDY.
+func dateOrderReadings(o opts) []reading {
+	dmy := reading{"day first", 1, WithDateOrder(DMY)}
+	mdy := reading{"month first", 1, WithDateOrder(MDY)}
+	switch o.dateOrder {
+	case DMY:
+		dmy.weight = 2
+	case MDY:
+		mdy.weight = 2
+		return []reading{mdy, dmy}
+	}
+	return []reading{dmy, mdy}
+}
+
+// bareNumberReadings returns the readings of a number on its own at the start
+// of s, as in "12", as whichever of a day of the month, a month and an hour it
+// can be, or nil if there is no such number.
+func bareNumberReadings(s string) []reading {
+	fields := strings.Fields(s)
+	if len(fields) == 0 || !bareNumberRx.MatchString(fields[0]) {
+		return nil
+	}
+	n, _ := strconv.Atoi(fields[0])
+	var rs []reading
+	if 1 <= n && n <= 31 {
+		rs = append(rs, reading{"day of the month", 1, withBareNumbers(bareDay)})
+	}
+	if 1 <= n && n <= 12 {
+		rs = append(rs, reading{"month", 1, withBareNumbers(bareMonth)})
+	}
+	if n <= 23 {
+		rs = append(rs, reading{"hour", 1, withBareNumbers(bareHour)})
+	}
+	return rs
+}
+
+// withBareNumbers sets the option to read numbers on their own as given by
+// b.
+func withBareNumbers(b bareNumberReading) func(o *opts) {
+	return func(o *opts) {
+		o.bareNumber = b
+	}
+}
+
+// bareNumber returns a parser of numbers on their own relative to ref, as in
+// "12", read as o.bareNumber says. A day of the month or a month is the next
+// or last one after or before ref, as the default direction says, and an
+// hour is on the day of ref.
+func bareNumber(ref time.Time, o opts) gp.Parser {
+	return func(ps *gp.State, node *gp.Result) {
+		ps.WS(ps)
+		w := ps.Get()
+		if i := strings.IndexFunc(w, unicode.IsSpace); i >= 0 {
+			w = w[:i]
+		}
+		if o.bareNumber == noBareNumbers || !bareNumberRx.MatchString(w) {
+			ps.ErrorHere("number")
+			return
+		}
+		n, _ := strconv.Atoi(w)
+		r, ok := readBareNumber(n, ref, o)
+		if !ok {
+			ps.ErrorHere("number")
+			return
+		}
+		node.Token = w
+		node.Result = r
+		ps.Advance(len(w))
+	}
+}
+
+// readBareNumber returns the range meant by the number n on its own, read as
+// described for bareNumber, and whether it can be read that way.
+func readBareNumber(n int, ref time.Time, o opts) (Range, bool) {
+	switch o.bareNumber {
+	case bareDay:
+		if n < 1 || n > 31 {
+			return Range{}, false
+		}
+		step := 1
+		if o.defaultDirection == past {
+			step = -1
+		}
+		// Some month within a year has every day of the month.
+		for i := 0; i <= 12; i++ {
+			first := time.Date(ref.Year(), ref.Month()+time.Month(step*i), 1, 0, 0, 0, 0, ref.Location())
+			if !okDate(first.Year(), int(first.Month()), n) {
+				continue
+			}
+			d := first.AddDate(0, 0, n-1)
+			if (o.defaultDirection == past && d.Before(truncateDay(ref).Time)) || (o.defaultDirection != past && d.After(ref)) {
+				return truncateDay(d), true
+			}
+		}
+	case bareMonth:
+		if 1 <= n && n <= 12 {
+			if o.defaultDirection == past {
+				return prevMonth(ref, time.Month(n)), true
+			}
+			return nextMonth(ref, time.Month(n)), true
+		}
+	case bareHour:
+		if n <= 23 {
+			y, m, d := ref.Date()
+			return Range{time.Date(y, m, d, n, 0, 0, 0, ref.Location()), time.Hour - time.Second, Hour}, true
+		}
+	}
+	return Range{}, false
+}
+
+// zoneReadings returns the readings of the first ambiguous time zone
+// abbreviation in s, favoring the zone picked by the zone policy in the
+// options, or nil if there is no such abbreviation.
+func zoneReadings(s string, ref time.Time, options []func(o *opts)) []reading {
+	var o opts
+	for _, optFunc := range options {
+		optFunc(&o)
+	}
+	policy := o.zonePolicy
+	if policy == nil {
+		policy = FirstZone
+	}
+	var abbrev string
+	var zones []Zone
+	record := func(a string, zs []Zone) (*time.Location, bool) {
+		if zones == nil {
+			abbrev, zones = a, zs
+		}
+		return FirstZone(a, zs)
+	}
+	// Get past any ambiguous numeric date to the zone.
+	probeOptions := append([]func(o *opts){}, options...)
+	probeOptions = append(probeOptions, WithDateOrder(DMY), WithZonePolicy(record))
+	_, _ = ParseRange(s, ref, probeOptions...)
+	if zones == nil {
+		return nil
+	}
+	picked, _ := policy(abbrev, zones)
+	var rs []reading
+	for _, z := range zones {
+		rd := reading{z.Name, 1, WithZonePolicy(PreferZones(z.Name))}
+		if z.Location == picked {
+			rd.weight = 2
+		}
+		rs = append(rs, rd)
+	}
+	return rs
+}
+
+// combineReadings returns every combination of one reading from each of
+// dims.
+func combineReadings(dims [][]reading) [][]reading {
+	combos := [][]reading{nil}
+	for _, dim := range dims {
+		var next [][]reading
+		for _, c := range combos {
+			for _, rd := range dim {
+				next = append(next, append(append([]reading(nil), c...), rd))
+			}
+		}
+		combos = next
+	}
+	return combos
+}
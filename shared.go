@@ -0,0 +1,336 @@
+package anytime
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	gp "github.com/ijt/goparsify"
+)
+
+// maxSharedTokens limits how far sharedRange looks for a connector and for
+// the end of a range, so that it stays cheap when it is tried at every word
+// of a long text.
+const maxSharedTokens = 8
+
+// dashes are the characters that can join the ends of a range within a
+// word, as in "3-5", "mon–fri" or "2019—2021".
+const dashes = "-–—"
+
+// sharedWordRx matches the words that sharedRange splits its input into.
+var sharedWordRx = regexp.MustCompile(`[^\s,]+`)
+
+// sharedHourRx matches an hour from 1 to 12, possibly followed by "am" or
+// "pm".
+var sharedHourRx = regexp.MustCompile(`(?i)^(1[0-2]|0?[1-9])(am|pm)?$`)
+
+// token is a word of a string, or one of the parts of a word split at a dash
+// as in "9am-5pm".
+type token struct {
+	// start and end are the indices of the token in the string.
+	start, end int
+
+	// text is what to parse for the token. It is the token, possibly with
+	// words added to it, such as "pm" after a bare hour.
+	text string
+}
+
+// tokenize splits s into at most max tokens. Words with a single dash between
+// other characters, such as "3-5", are split into the parts on either side of
+// the dash and the dash itself.
+func tokenize(s string, max int) []token {
+	var tokens []token
+	for _, loc := range sharedWordRx.FindAllStringIndex(s, max) {
+		sow, eow := loc[0], loc[1]
+		w := s[sow:eow]
+		i := strings.IndexAny(w, dashes)
+		if i <= 0 || strings.IndexAny(w[i+1:], dashes) >= 0 {
+			tokens = append(tokens, token{sow, eow, w})
+			continue
+		}
+		_, n := utf8.DecodeRuneInString(w[i:])
+		if i+n == len(w) {
+			tokens = append(tokens, token{sow, eow, w})
+			continue
+		}
+		tokens = append(tokens,
+			token{sow, sow + i, w[:i]},
+			token{sow + i, sow + i + n, w[i : i+n]},
+			token{sow + i + n, eow, w[i+n:]})
+	}
+	if len(tokens) > max {
+		tokens = tokens[:max]
+	}
+	return tokens
+}
+
+// joinTokens joins the texts of the tokens with spaces, returning the joined
+// text and the indices in it just past each token.
+func joinTokens(tokens []token) (string, []int) {
+	var b strings.Builder
+	ends := make([]int, len(tokens))
+	for i, t := range tokens {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(t.text)
+		ends[i] = b.Len()
+	}
+	return b.String(), ends
+}
+
+// isConnector reports whether w joins the start and end of a range, as "to"
+// and "–" do.
+func isConnector(w string) bool {
+	switch strings.ToLower(w) {
+	case "to", "until", "through", "til", "'til", "till", "-", "–", "—":
+		return true
+	}
+	return false
+}
+
+// parsePrefix runs p at the start of s, returning the range it results in
+// and the index in s just past what it parsed.
+func parsePrefix(p gp.Parser, s string) (Range, int, bool) {
+	ps := gp.NewState(s)
+	ps.WS = gp.UnicodeWhitespace
+	var node gp.Result
+	p(ps, &node)
+	if ps.Errored() {
+		return Range{}, 0, false
+	}
+	r, ok := node.Result.(Range)
+	return r, ps.Pos, ok
+}
+
+// sharedRange returns a parser of explicit ranges whose start or end leaves
+// out things that are given by the other, as in "march 3-5, 2022", "may 3 to
+// 7", "3rd to 5th of may", "march 3 to april 5 2022", "mon-fri next week",
+// "9am-5pm tomorrow", "9 to 5 on tuesday" or "2019–2021 AD", with the ends
+// parsed by p. The start may take words from the end of the range and the end
+// may take words from the start of the range, so long as that gives complete
+// dates. Bare hours, as in "9 to 5", are taken to be during the working day
+// unless "am" or "pm" says otherwise, and need a day or an "am" or "pm" to be
+// recognized. Ranges whose ends are complete on their own are left to
+// RangeParser.
+func sharedRange(p gp.Parser) gp.Parser {
+	return func(ps *gp.State, node *gp.Result) {
+		pos := ps.Pos
+		ps.WS(ps)
+		s := ps.Get()
+		tokens := tokenize(s, 2*maxSharedTokens+1)
+		if len(tokens) > 0 && strings.EqualFold(tokens[0].text, "from") {
+			tokens = tokens[1:]
+		}
+		c := -1
+		for i, t := range tokens {
+			if i > maxSharedTokens {
+				break
+			}
+			if isConnector(t.text) {
+				c = i
+				break
+			}
+		}
+		if c < 1 || c == len(tokens)-1 {
+			ps.Pos = pos
+			ps.ErrorHere("a range with shared parts")
+			return
+		}
+		if len(tokens)-c-1 > maxSharedTokens {
+			tokens = tokens[:c+1+maxSharedTokens]
+		}
+
+		// Bare numbers are first taken to be days, as in "may 3 to 7", and
+		// then hours, as in "9 to 5 on tuesday".
+		for _, hours := range []bool{false, true} {
+			start := append([]token{}, tokens[:c]...)
+			end := append([]token{}, tokens[c+1:]...)
+			inferred, needsDay := inferShared(start, end, hours)
+			if r, n, ok := shareRange(p, start, end, inferred, needsDay); ok {
+				node.Result = r
+				node.Token = s[:n]
+				ps.Advance(n)
+				return
+			}
+		}
+		ps.Pos = pos
+		ps.ErrorHere("a range with shared parts")
+	}
+}
+
+// shareRange is the part of sharedRange that parses the range with the
+// tokens start before the connector and end after it, given whether
+// inferShared changed any of them and whether the range needs a day. It
+// returns the range and the index just past it in the string that was split
+// into tokens.
+func shareRange(p gp.Parser, start, end []token, inferred, needsDay bool) (Range, int, bool) {
+	// The end, taking as many words as possible from the start, as in "may 3
+	// to 7", so long as it covers at least the first word after the
+	// connector and is as fine as the end on its own, if that can be parsed.
+	alone, _ := joinTokens(end)
+	endAlone, n, ok := parsePrefix(p, alone)
+	endAloneOK := ok && n == len(alone)
+	var endRange Range
+	var endWords []token
+	var nEnd int
+	for j := len(start) - 1; j >= 0 && endWords == nil; j-- {
+		words := append(append([]token{}, start[:j]...), end...)
+		text, ends := joinTokens(words)
+		er, n, ok := parsePrefix(p, text)
+		if !ok || n < ends[j] || (j > 0 && endAloneOK && er.Granularity != endAlone.Granularity) {
+			continue
+		}
+		for nEnd = 0; j+nEnd < len(words) && ends[j+nEnd] <= n; nEnd++ {
+		}
+		endRange, endWords = er, words[:j+nEnd]
+		if j > 0 {
+			inferred, needsDay = true, false
+		}
+	}
+	if endWords == nil {
+		return Range{}, 0, false
+	}
+
+	// The start, taking as many words as possible from the end of the range,
+	// as in "march 3 to april 5 2022", so long as it covers all of the start
+	// and is as fine as the start on its own, if that can be parsed, so that
+	// "today to tomorrow at 5pm" starts at the beginning of today.
+	alone, _ = joinTokens(start)
+	startAlone, n, ok := parsePrefix(p, alone)
+	startAloneOK := ok && n == len(alone)
+	var startRange Range
+	found := false
+	for k := 1; k <= nEnd && !found; k++ {
+		words := append(append([]token{}, start...), end[k:nEnd]...)
+		text, _ := joinTokens(words)
+		sr, n, ok := parsePrefix(p, text)
+		if !ok || n != len(text) || (k < nEnd && startAloneOK && sr.Granularity != startAlone.Granularity) {
+			continue
+		}
+		startRange, found = sr, true
+		if k < nEnd {
+			inferred, needsDay = true, false
+		}
+	}
+	if !found || !inferred || needsDay {
+		return Range{}, 0, false
+	}
+
+	r := Range{
+		startRange.Time,
+		endRange.Sub(startRange.Time),
+		finer(startRange.Granularity, endRange.Granularity),
+	}
+	return r, end[nEnd-1].end, true
+}
+
+// inferShared fills in what is left out of years and, if hours is true, bare
+// hours at the ends of the start and end of a range, as in "2019–2021" or "9
+// to 5", by changing the texts of their tokens. It returns whether it changed
+// any, and whether the range needs to be on a given day to be taken for one,
+// as with "9 to 5", which could just as well be a range of numbers.
+func inferShared(start, end []token, hours bool) (changed, needsDay bool) {
+	s, e := &start[len(start)-1], &end[0]
+
+	// Years, as in "2019–2021"
+	if len(start) == 1 && isYear(s.text) && isYear(e.text) {
+		s.text += " AD"
+		if len(end) == 1 || !isEra(end[1].text) {
+			e.text += " AD"
+		}
+		return true, false
+	}
+
+	// Hours, as in "9 to 5" or "9-11am"
+	if !hours {
+		return false, false
+	}
+	h1, m1, ok1 := hourAndMeridiem(start, len(start)-1)
+	h2, m2, ok2 := hourAndMeridiem(end, 0)
+	if !ok1 || !ok2 || (m1 != "" && m2 != "") {
+		return false, false
+	}
+	bare1, bare2 := m1 == "", m2 == ""
+	switch {
+	case m1 == "" && m2 == "":
+		m1 = "am"
+		if h1 < 7 || h1 == 12 {
+			m1 = "pm"
+		}
+		m2 = meridiemAfter(h1, m1, h2)
+		needsDay = true
+	case m1 == "":
+		m1 = m2
+		if hour24(h1, m1) >= hour24(h2, m2) {
+			m1 = "am"
+		}
+	default:
+		m2 = meridiemAfter(h1, m1, h2)
+	}
+	if bare1 {
+		s.text += m1
+	}
+	if bare2 {
+		e.text += m2
+	}
+	return true, needsDay
+}
+
+// isYear reports whether w is a four-digit year.
+func isYear(w string) bool {
+	y, err := strconv.Atoi(w)
+	return err == nil && len(w) == 4 && 1000 <= y && y < 3000
+}
+
+// isEra reports whether w is "AD" or "CE".
+func isEra(w string) bool {
+	return strings.EqualFold(w, "ad") || strings.EqualFold(w, "ce")
+}
+
+// hourAndMeridiem returns the hour from 1 to 12 of a bare hour like "9" at
+// tokens[i], along with "am" or "pm" if it is followed by one, or of an hour
+// like "9am", or of a bare hour followed by "am" or "pm" ending at
+// tokens[i].
+func hourAndMeridiem(tokens []token, i int) (h int, meridiem string, ok bool) {
+	w := tokens[i].text
+	if i > 0 && isMeridiem(w) {
+		meridiem, w = strings.ToLower(w), tokens[i-1].text
+	} else if i+1 < len(tokens) && isMeridiem(tokens[i+1].text) {
+		meridiem = strings.ToLower(tokens[i+1].text)
+	}
+	sm := sharedHourRx.FindStringSubmatch(w)
+	if sm == nil || (sm[2] != "" && meridiem != "") {
+		return 0, "", false
+	}
+	if sm[2] != "" {
+		meridiem = strings.ToLower(sm[2])
+	}
+	h, _ = strconv.Atoi(sm[1])
+	return h, meridiem, true
+}
+
+// isMeridiem reports whether w is "am" or "pm".
+func isMeridiem(w string) bool {
+	return strings.EqualFold(w, "am") || strings.EqualFold(w, "pm")
+}
+
+// hour24 returns the hour on the 24-hour clock of hour h from 1 to 12 with
+// the meridiem "am" or "pm".
+func hour24(h int, meridiem string) int {
+	h %= 12
+	if meridiem == "pm" {
+		h += 12
+	}
+	return h
+}
+
+// meridiemAfter returns "am" or "pm", whichever makes the hour h2 first come
+// after the hour h1 with the meridiem m1.
+func meridiemAfter(h1 int, m1 string, h2 int) string {
+	if hour24(h2, "am") > hour24(h1, m1) {
+		return "am"
+	}
+	return "pm"
+}
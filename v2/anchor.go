@@ -0,0 +1,128 @@
+package anytime
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// Portion is a part of a period, running from the fraction From of the way
+// through it to the fraction To.
+type Portion struct {
+	From, To float64
+}
+
+// PeriodPortions are the parts of a period meant by "early", "mid" and
+// "late", as in "early march", "mid-2023" and "late last year".
+type PeriodPortions struct {
+	Early, Mid, Late Portion
+}
+
+// thirds are the default period portions, which split periods into thirds so
+// that "early march" is March 1 to 10, "mid-march" is March 11 to 21 and
+// "late march" is March 22 to 31.
+var thirds = PeriodPortions{
+	Early: Portion{0, 1.0 / 3},
+	Mid:   Portion{1.0 / 3, 2.0 / 3},
+	Late:  Portion{2.0 / 3, 1},
+}
+
+// defaultEndOfDayHour is the hour meant by "EOD" and "COB" by default, as in
+// 5pm.
+const defaultEndOfDayHour = 17
+
+// of returns the days of r that make up the portion p of it, with at least
+// one day.
+func (p Portion) of(r Range) Range {
+	days := r.Days()
+	from := int(math.Round(p.From * float64(len(days))))
+	to := int(math.Round(p.To * float64(len(days))))
+	if from < 0 {
+		from = 0
+	}
+	if from > len(days)-1 {
+		from = len(days) - 1
+	}
+	if to <= from {
+		to = from + 1
+	}
+	if to > len(days) {
+		to = len(days)
+	}
+	return Range{days[from].start, days[to-1].End().Sub(days[from].start), Day}
+}
+
+// parseAnchor parses a part of a period, as in "end of this month", "start of
+// next week", "beginning of q3", "mid-2023", "early march" or "late last
+// year", or the end of a day, as in "EOD friday" or "COB tomorrow", at the
+// first word at or after index start of s. The start and end of a period are
+// its first and last days, early, mid and late are given by o.portions, and
+// the end of a day is the hour o.endOfDayHour. It returns the range, the
+// index just past the expression, the kind of expression and whether one was
+// found.
+func parseAnchor(s string, start int, now time.Time, o opts) (Range, int, Kind, bool) {
+	sow, eow, w := findSignalNoise(s, start)
+	if eq(w, "the") {
+		sow, eow, w = findSignalNoise(s, eow)
+	}
+	_, eow2, w2 := findSignalNoise(s, eow)
+	_, eow3, w3 := findSignalNoise(s, eow2)
+	_, eow4, w4 := findSignalNoise(s, eow3)
+
+	// End of a day, as in "EOD", "COB tomorrow" or "end of day on friday"
+	eod := -1
+	switch {
+	case w == "eod" || w == "cob":
+		eod = eow
+	case (w == "end" || w == "close") && w2 == "of" && (w3 == "day" || w3 == "business"):
+		eod = eow3
+	case w == "end" && w2 == "of" && w3 == "the" && w4 == "day":
+		eod = eow4
+	}
+	if eod >= 0 {
+		day, kind := truncateDay(now), Relative
+		_, eoon, on := findSignalNoise(s, eod)
+		if on != "on" {
+			eoon = eod
+		}
+		sod := findNextSignal(s, eoon)
+		if d, parsedD, k, err := parseImplicitDateRange(s[sod:], now, o); err == nil && isDay(d) {
+			day, kind, eod = d, k, sod+len(parsedD)
+		}
+		c := clock{hour: o.endOfDayHour, granularity: Hour}
+		return c.on(day), eod, kind, true
+	}
+
+	// Part of a period, as in "end of the month" or "mid-march"
+	var sop int
+	var part func(p Range) Range
+	first := func(p Range) Range { return p.Days()[0] }
+	last := func(p Range) Range { days := p.Days(); return days[len(days)-1] }
+	switch {
+	case (w == "start" || w == "beginning") && w2 == "of":
+		sop, part = eow2, first
+	case w == "end" && w2 == "of":
+		sop, part = eow2, last
+	case w == "middle" && w2 == "of":
+		sop, part = eow2, o.portions.Mid.of
+	case w == "mid":
+		sop, part = eow, o.portions.Mid.of
+	case strings.HasPrefix(w, "mid-"):
+		sop, part = sow+len("mid-"), o.portions.Mid.of
+	case w == "early" || w == "late":
+		sop, part = eow, o.portions.Early.of
+		if w == "late" {
+			part = o.portions.Late.of
+		}
+		if w2 == "in" {
+			sop = eow2
+		}
+	default:
+		return Range{}, 0, 0, false
+	}
+	p, eop, kind, ok := parseCoarseRange(s, sop, now, o)
+	if !ok {
+		return Range{}, 0, 0, false
+	}
+	return part(p), eop, kind, true
+}
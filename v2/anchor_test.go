@@ -0,0 +1,48 @@
+package anytime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_anchors(t *testing.T) {
+	halves := PeriodPortions{Early: Portion{0, 0.5}, Mid: Portion{0.25, 0.75}, Late: Portion{0.5, 1}}
+	testParse(t, []parseTest{
+		{"end of this month", nil, testDay(2022, 9, 30)},
+		{"the end of the month", nil, testDay(2022, 9, 30)},
+		{"end of month", nil, testDay(2022, 9, 30)},
+		{"start of next week", nil, testDay(2022, 10, 2)},
+		{"start of next week", []func(o *opts){WeekStartsOn(time.Monday)}, testDay(2022, 10, 3)},
+		{"beginning of next year", nil, testDay(2023, 1, 1)},
+		{"beginning of q3", nil, testDay(2023, 7, 1)},
+		{"end of q3 2022", nil, testDay(2022, 9, 30)},
+		{"mid-march", nil, testDays(2023, 3, 11, 11)},
+		{"mid march", nil, testDays(2023, 3, 11, 11)},
+		{"the middle of march", nil, testDays(2023, 3, 11, 11)},
+		{"mid-2023", nil, testDays(2023, 5, 3, 121)},
+		{"early march", nil, testDays(2023, 3, 1, 10)},
+		{"early in march", []func(o *opts){DefaultToPast}, testDays(2022, 3, 1, 10)},
+		{"late march", nil, testDays(2023, 3, 22, 10)},
+		{"late last year", nil, testDays(2021, 9, 1, 122)},
+		{"early march", []func(o *opts){WithPeriodPortions(halves)}, testDays(2023, 3, 1, 16)},
+		{"mid-march", []func(o *opts){WithPeriodPortions(halves)}, testDays(2023, 3, 9, 15)},
+		{"late march", []func(o *opts){WithPeriodPortions(halves)}, testDays(2023, 3, 17, 15)},
+		{"EOD", nil, testHours(2022, 9, 29, 17, 1)},
+		{"EOD friday", nil, testHours(2022, 9, 30, 17, 1)},
+		{"COB tomorrow", nil, testHours(2022, 9, 30, 17, 1)},
+		{"close of business on monday", nil, testHours(2022, 10, 3, 17, 1)},
+		{"end of day", []func(o *opts){WithEndOfDayHour(18)}, testHours(2022, 9, 29, 18, 1)},
+		{"end of the day march 3 2023", nil, testHours(2023, 3, 3, 17, 1)},
+	})
+}
+
+func TestParse_anchorsFail(t *testing.T) {
+	testParseFail(t, []string{"end of", "the middle of", "mid-", "early", "late in", "end of the blah"})
+}
+
+func TestFindAll_anchors(t *testing.T) {
+	matches := FindAll("ship it by the end of next month, not early tomorrow", testNow)
+	if len(matches) != 2 || matches[0].Src != "the end of next month" || matches[1].Src != "tomorrow" {
+		t.Errorf("got %+v", matches)
+	}
+}
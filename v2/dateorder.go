@@ -0,0 +1,125 @@
+package anytime
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// ErrAmbiguousDate is returned when a numeric date such as "03/04/2022" could
+// be read either day first or month first and no DateOrder was given to say
+// which.
+var ErrAmbiguousDate = errors.New("ambiguous numeric date")
+
+// errNoNumericDateFound is returned by parseNumericDate when there is no
+// numeric date at all.
+var errNoNumericDateFound = errors.New("no numeric date found")
+
+// DateOrder is the order in which the day, month and year of numeric dates
+// like "03/04/2022" are written.
+type DateOrder int
+
+const (
+	// NoDateOrder reads numeric dates whichever way makes them valid, as in
+	// "31/03/2022" and "03/31/2022", and rejects those like "03/04/2022"
+	// that are valid either way with ErrAmbiguousDate.
+	NoDateOrder DateOrder = iota
+
+	// DMY reads numeric dates day first, as in "03/04/2022" for 3 April.
+	DMY
+
+	// MDY reads numeric dates month first, as in "03/04/2022" for March 4.
+	MDY
+
+	// YMD reads numeric dates year first, as in "2022-04-03". Dates with the
+	// year last are read as they are with NoDateOrder.
+	YMD
+)
+
+// numericDateRx matches numeric dates with the year first or last, separated
+// by slashes, dashes or dots, as in "2022-04-03", "03/04/2022" or
+// "03.04.2022".
+var numericDateRx = regexp.MustCompile(`^(?:(\d{4})([-/.])(\d{1,2})([-/.])(\d{1,2})|(\d{1,2})([-/.])(\d{1,2})([-/.])(\d{4}))\b`)
+
+// yearlessDateRx matches numeric dates with no year, separated by a slash, as
+// in "3/4".
+var yearlessDateRx = regexp.MustCompile(`^(\d{1,2})/(\d{1,2})\b`)
+
+// parseNumericDate parses a numeric date at the beginning of s, reading it in
+// the given order. Dates with no year, as in "3/4", are only read if order is
+// DMY or MDY, since things like "24/7" and "1/2" are seldom dates. It returns
+// the date and the length of the text it was parsed from,
+// errNoNumericDateFound if there is no valid numeric date there, or
+// ErrAmbiguousDate if order does not say how to read it.
+func parseNumericDate(s string, order DateOrder) (date, int, error) {
+	m := numericDateRx.FindStringSubmatch(s)
+	if m == nil {
+		return parseYearlessDate(s, order)
+	}
+	if m[1] != "" {
+		// YYYY/MM/DD
+		if m[2] != m[4] {
+			return date{}, 0, errNoNumericDateFound
+		}
+		y, _ := strconv.Atoi(m[1])
+		mo, _ := strconv.Atoi(m[3])
+		dom, _ := strconv.Atoi(m[5])
+		if !okDate(y, mo, dom) {
+			return date{}, 0, errNoNumericDateFound
+		}
+		return date{year: y, month: time.Month(mo), dayOfMonth: dom}, len(m[0]), nil
+	}
+
+	// DD/MM/YYYY or MM/DD/YYYY
+	if m[7] != m[9] {
+		return date{}, 0, errNoNumericDateFound
+	}
+	a, _ := strconv.Atoi(m[6])
+	b, _ := strconv.Atoi(m[8])
+	y, _ := strconv.Atoi(m[10])
+	okDMY := okDate(y, b, a)
+	okMDY := okDate(y, a, b)
+	switch order {
+	case DMY:
+		okMDY = false
+	case MDY:
+		okDMY = false
+	}
+	switch {
+	case okDMY && okMDY && a != b:
+		return date{}, 0, ErrAmbiguousDate
+	case okDMY:
+		return date{year: y, month: time.Month(b), dayOfMonth: a}, len(m[0]), nil
+	case okMDY:
+		return date{year: y, month: time.Month(a), dayOfMonth: b}, len(m[0]), nil
+	}
+	return date{}, 0, errNoNumericDateFound
+}
+
+// parseYearlessDate is the part of parseNumericDate that parses dates with no
+// year.
+func parseYearlessDate(s string, order DateOrder) (date, int, error) {
+	m := yearlessDateRx.FindStringSubmatch(s)
+	if m == nil || (order != DMY && order != MDY) {
+		return date{}, 0, errNoNumericDateFound
+	}
+	a, _ := strconv.Atoi(m[1])
+	b, _ := strconv.Atoi(m[2])
+	if order == DMY {
+		a, b = b, a
+	}
+	// Leap years have every day that any year has.
+	if !okDate(2000, a, b) {
+		return date{}, 0, errNoNumericDateFound
+	}
+	return date{month: time.Month(a), dayOfMonth: b}, len(m[0]), nil
+}
+
+// okDate returns whether there is a day dom in month m of year y.
+func okDate(y, m, dom int) bool {
+	if m < 1 || m > 12 || dom < 1 {
+		return false
+	}
+	return dom <= time.Date(y, time.Month(m)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
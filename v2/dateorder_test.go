@@ -0,0 +1,92 @@
+package anytime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_dateOrder(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	tests := []struct {
+		order   DateOrder
+		input   string
+		want    Range
+		wantErr error
+	}{
+		{NoDateOrder, "2022-03-04", truncateDay(time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC)), nil},
+		{NoDateOrder, "2022.03.04", truncateDay(time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC)), nil},
+		{NoDateOrder, "31/03/2022", truncateDay(time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC)), nil},
+		{NoDateOrder, "03/31/2022", truncateDay(time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC)), nil},
+		{NoDateOrder, "04.04.2022", truncateDay(time.Date(2022, 4, 4, 0, 0, 0, 0, time.UTC)), nil},
+		{NoDateOrder, "03/04/2022", Range{}, ErrAmbiguousDate},
+		{NoDateOrder, "03-04-2022", Range{}, ErrAmbiguousDate},
+		{NoDateOrder, "03.04.2022", Range{}, ErrAmbiguousDate},
+		{NoDateOrder, "5pm 03.04.2022", Range{}, ErrAmbiguousDate},
+		{YMD, "03/04/2022", Range{}, ErrAmbiguousDate},
+		{DMY, "03/04/2022", truncateDay(time.Date(2022, 4, 3, 0, 0, 0, 0, time.UTC)), nil},
+		{DMY, "03-04-2022", truncateDay(time.Date(2022, 4, 3, 0, 0, 0, 0, time.UTC)), nil},
+		{DMY, "03.04.2022 UTC+1", truncateDay(time.Date(2022, 4, 3, 0, 0, 0, 0, fixedZone(1))), nil},
+		{DMY, "2022-03-04", truncateDay(time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC)), nil},
+		{MDY, "03/04/2022", truncateDay(time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC)), nil},
+		{MDY, "03.04.2022 at 5pm", Range{time.Date(2022, 3, 4, 17, 0, 0, 0, time.UTC), time.Hour, Hour}, nil},
+		{MDY, "2022/03/04", truncateDay(time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC)), nil},
+		{DMY, "3/4", truncateDay(time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC)), nil},
+		{MDY, "3/4", truncateDay(time.Date(2023, 3, 4, 0, 0, 0, 0, time.UTC)), nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, parsed, err := Parse(tt.input, now, WithDateOrder(tt.order))
+			if err != tt.wantErr {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("got range %v, want %v", got, tt.want)
+			}
+			if err == nil && parsed != tt.input {
+				t.Errorf("parsed %q, want %q", parsed, tt.input)
+			}
+		})
+	}
+}
+
+func TestParse_dateOrderFail(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	tests := []struct {
+		order DateOrder
+		input string
+	}{
+		{DMY, "03/31/2022"},
+		{MDY, "31/03/2022"},
+		{NoDateOrder, "31/31/2022"},
+		{NoDateOrder, "30/02/2022"},
+		{NoDateOrder, "2022-02-30"},
+		{NoDateOrder, "3/4"},
+		{DMY, "3/31"},
+		{MDY, "3-4"},
+		{NoDateOrder, "2022/03.04"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, parsed, err := Parse(tt.input, now, WithDateOrder(tt.order))
+			if err == nil && parsed == tt.input {
+				t.Errorf("got %v from %q, want error", got, parsed)
+			}
+		})
+	}
+}
+
+func TestFindAll_ambiguousDate(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	s := "due 03.04.2022 or 31.03.2022"
+	matches := FindAll(s, now)
+	if len(matches) != 1 {
+		t.Fatalf("got %d matches, want 1: %v", len(matches), matches)
+	}
+	if got, want := matches[0].Src, "31.03.2022"; got != want {
+		t.Errorf("found %q, want %q", got, want)
+	}
+	matches = FindAll(s, now, WithDateOrder(MDY))
+	if len(matches) != 1 || matches[0].Src != "03.04.2022" {
+		t.Errorf("got %v, want just 03.04.2022", matches)
+	}
+}
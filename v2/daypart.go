@@ -0,0 +1,70 @@
+package anytime
+
+import "time"
+
+// DayPart is a part of a day running from the hour From to the hour To, with
+// hours past 24 running into the next day, so that {22, 30} is from 10pm to
+// 6am the next morning.
+type DayPart struct {
+	From, To int
+}
+
+// defaultDayParts returns the parts of a day meant by words like "morning"
+// by default, as in "tomorrow morning", "friday evening" or "tonight".
+func defaultDayParts() map[string]DayPart {
+	return map[string]DayPart{
+		"morning":   {6, 12},
+		"afternoon": {12, 18},
+		"evening":   {18, 22},
+		"night":     {18, 30},
+		"overnight": {22, 30},
+		"midnight":  {24, 25},
+	}
+}
+
+// on returns the range of the part p of the day starting range d.
+func (p DayPart) on(d Range) Range {
+	y, m, dom := d.start.Date()
+	loc := d.start.Location()
+	start := time.Date(y, m, dom, p.From, 0, 0, 0, loc)
+	end := time.Date(y, m, dom, p.To, 0, 0, 0, loc)
+	return Range{start, end.Sub(start), Hour}
+}
+
+// parseDayPart parses the name of a part of a day in o.dayParts, such as
+// "morning", at the first word at or after index start of s, returning the
+// part, the index just past its name and whether one was found.
+func parseDayPart(s string, start int, o opts) (DayPart, int, bool) {
+	_, eow, w := findSignalNoise(s, start)
+	p, ok := o.dayParts[w]
+	return p, eow, ok
+}
+
+// parseDayPartAlone parses a part of a day that is not attached to a date, at
+// the first word at or after index start of s: "tonight", "last night",
+// "midnight" and "overnight", meaning the night just gone in the case of
+// "last night" and today's otherwise, or "this" followed by the name of any
+// part of a day, as in "this afternoon". It returns the range, the index just
+// past the expression and whether one was found.
+func parseDayPartAlone(s string, start int, now time.Time, o opts) (Range, int, bool) {
+	_, eow, w := findSignalNoise(s, start)
+	_, eow2, w2 := findSignalNoise(s, eow)
+	today := truncateDay(now)
+	var p DayPart
+	var ok bool
+	switch {
+	case w == "tonight":
+		p, ok = o.dayParts["night"]
+	case w == "midnight" || w == "overnight":
+		p, ok = o.dayParts[w]
+	case w == "last" && w2 == "night":
+		p, ok = o.dayParts["night"]
+		today, eow = truncateDay(now.AddDate(0, 0, -1)), eow2
+	case w == "this":
+		p, eow, ok = parseDayPart(s, eow, o)
+	}
+	if !ok {
+		return Range{}, 0, false
+	}
+	return p.on(today), eow, true
+}
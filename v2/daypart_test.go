@@ -0,0 +1,44 @@
+package anytime
+
+import "testing"
+
+func TestParse_dayParts(t *testing.T) {
+	testParse(t, []parseTest{
+		{"tomorrow morning", nil, testHours(2022, 9, 30, 6, 6)},
+		{"this afternoon", nil, testHours(2022, 9, 29, 12, 6)},
+		{"friday evening", nil, testHours(2022, 9, 30, 18, 4)},
+		{"friday evening", []func(o *opts){DefaultToPast}, testHours(2022, 9, 23, 18, 4)},
+		{"tonight", nil, testHours(2022, 9, 29, 18, 12)},
+		{"last night", nil, testHours(2022, 9, 28, 18, 12)},
+		{"tomorrow night", nil, testHours(2022, 9, 30, 18, 12)},
+		{"midnight", nil, testHours(2022, 9, 30, 0, 1)},
+		{"friday midnight", nil, testHours(2022, 10, 1, 0, 1)},
+		{"overnight", nil, testHours(2022, 9, 29, 22, 8)},
+		{"october 3 morning", nil, testHours(2022, 10, 3, 6, 6)},
+		{"tomorrow morning", []func(o *opts){WithDayParts(map[string]DayPart{"morning": {5, 11}})}, testHours(2022, 9, 30, 5, 6)},
+	})
+}
+
+func TestParse_dayPartsFail(t *testing.T) {
+	testParseFail(t, []string{"this morningtide", "last morning", "morning night"})
+}
+
+func TestWithDayParts_copies(t *testing.T) {
+	parts := map[string]DayPart{"morning": {5, 11}}
+	option := WithDayParts(parts)
+	parts["morning"] = DayPart{7, 8}
+	got, _, err := Parse("tomorrow morning", testNow, option)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := testHours(2022, 9, 30, 5, 6); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestFindAll_dayParts(t *testing.T) {
+	matches := FindAll("good morning, are we still on for tomorrow evening?", testNow)
+	if len(matches) != 1 || matches[0].Src != "tomorrow evening" {
+		t.Errorf("got %+v, want just tomorrow evening", matches)
+	}
+}
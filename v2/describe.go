@@ -0,0 +1,143 @@
+package anytime
+
+import (
+	"fmt"
+	"time"
+)
+
+// Describe returns a phrase for r relative to ref, such as "yesterday",
+// "next month", "3 days ago", "March 2023" or
+// "from January 3, 2023 to February 20, 2023". The phrase is one that
+// Parse accepts and turns back into r given the same ref and WeekStartsOn
+// option, whichever direction is used. Ranges that line up with a calendar period, such as
+// a day or a month, are described as that period, and any other range is
+// described by its start and end.
+//
+// The phrase is in the time zone of ref. Fractions of a second at either end
+// of a range are lost, except at ref itself, and years before 1000 or after 9999
+// cannot be written in a form ParseRange accepts.
+func Describe(r Range, ref time.Time, options ...func(o *opts)) string {
+	o := newOpts(options...)
+	loc := ref.Location()
+	r.start = r.start.In(loc)
+	if r.Equal(Range{ref, time.Second, Second}) {
+		return "now"
+	}
+	for _, g := range []Granularity{Year, Month, Week, Day, Hour, Minute, Second} {
+		u := o.unit(g)
+		if !u.truncate(r.start).Equal(r) {
+			continue
+		}
+		switch g {
+		case Year:
+			if s, ok := describeLastThisNext(r, ref, u, "year"); ok {
+				return s
+			}
+			return fmt.Sprintf("%d AD", r.start.Year())
+		case Month:
+			if s, ok := describeLastThisNext(r, ref, u, "month"); ok {
+				return s
+			}
+			return r.start.Format("January 2006")
+		case Week:
+			if s, ok := describeLastThisNext(r, ref, u, "week"); ok {
+				return s
+			}
+			if s, ok := describeUnitsAway(r, ref, u, "weeks", 52); ok {
+				return s
+			}
+		case Day:
+			if s, ok := describeUnitsAway(r, ref, u, "days", 6); ok {
+				return s
+			}
+			return describeDay(r.start, ref)
+		default:
+			return describeDay(r.start, ref) + " at " + describeClock(r.start, g)
+		}
+	}
+	return "from " + describeTime(r.start, ref) + " to " + describeTime(r.End(), ref)
+}
+
+// describeLastThisNext describes r as "last", "this" or "next" followed by
+// the name of the unit u, if that is what r is relative to ref.
+func describeLastThisNext(r Range, ref time.Time, u unit, name string) (string, bool) {
+	for i, word := range []string{"last", "this", "next"} {
+		if u.truncate(u.add(ref, i-1)).Equal(r) {
+			return word + " " + name, true
+		}
+	}
+	return "", false
+}
+
+// describeUnitsAway describes r as "N units ago" or "in N units" for the unit
+// u with the plural name given, if r is no more than max units from ref.
+// It does not describe r if it is within one unit of ref, since there are
+// more natural ways to say that.
+func describeUnitsAway(r Range, ref time.Time, u unit, plural string, max int) (string, bool) {
+	for n := 2; n <= max; n++ {
+		if u.truncate(u.add(ref, -n)).Equal(r) {
+			return fmt.Sprintf("%d %s ago", n, plural), true
+		}
+		if u.truncate(u.add(ref, n)).Equal(r) {
+			return fmt.Sprintf("in %d %s", n, plural), true
+		}
+	}
+	return "", false
+}
+
+// describeDay describes the day of t as "yesterday", "today", "tomorrow"
+// or a date such as "March 3, 2023".
+func describeDay(t time.Time, ref time.Time) string {
+	d := truncateDay(t)
+	for i, word := range []string{"yesterday", "today", "tomorrow"} {
+		if truncateDay(ref.AddDate(0, 0, i-1)).Equal(d) {
+			return word
+		}
+	}
+	return t.Format("January 2, 2006")
+}
+
+// describeClock describes the time of day of t to the given granularity,
+// which should be Hour, Minute or Second. Times in the hour that is repeated
+// when daylight saving time ends are followed by their offset from UTC, as
+// in "1:30am utc-5", so that they are not read as the first such time.
+func describeClock(t time.Time, g Granularity) string {
+	var s string
+	switch {
+	case g == Hour && t.Hour() == 12:
+		s = "noon"
+	case g == Hour:
+		s = t.Format("3pm")
+	case g == Minute:
+		s = t.Format("3:04pm")
+	default:
+		s = t.Format("3:04:05pm")
+	}
+	y, m, d := t.Date()
+	if !time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), t.Location()).Equal(t) {
+		_, offset := t.Zone()
+		s += fmt.Sprintf(" utc%+d", offset/3600)
+	}
+	return s
+}
+
+// describeTime describes the instant t as one end of an explicit range. It
+// names the day if t is at the start of one, and otherwise gives the time of
+// day as precisely as needed. Fractions of a second are dropped, since
+// ParseRange has no way to read them unless t is ref itself.
+func describeTime(t time.Time, ref time.Time) string {
+	if !t.Equal(ref) {
+		t = truncateSecond(t).start
+	}
+	switch {
+	case t.Equal(ref):
+		return "now"
+	case truncateDay(t).start.Equal(t):
+		return describeDay(t, ref)
+	case t.Second() != 0:
+		return describeDay(t, ref) + " at " + describeClock(t, Second)
+	case t.Minute() != 0:
+		return describeDay(t, ref) + " at " + describeClock(t, Minute)
+	}
+	return describeDay(t, ref) + " at " + describeClock(t, Hour)
+}
@@ -0,0 +1,127 @@
+package anytime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDescribe(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	date := func(y int, m time.Month, d, h, min, s int) time.Time {
+		return time.Date(y, m, d, h, min, s, 0, time.UTC)
+	}
+	tests := []struct {
+		r    Range
+		want string
+	}{
+		{Range{now, time.Second, Second}, "now"},
+		{truncateDay(now.AddDate(0, 0, -1)), "yesterday"},
+		{truncateDay(now), "today"},
+		{truncateDay(now.AddDate(0, 0, 1)), "tomorrow"},
+		{truncateDay(now.AddDate(0, 0, -3)), "3 days ago"},
+		{truncateDay(now.AddDate(0, 0, 5)), "in 5 days"},
+		{truncateDay(date(2022, 3, 3, 0, 0, 0)), "March 3, 2022"},
+		{truncateWeek(now.AddDate(0, 0, -7)), "last week"},
+		{truncateWeek(now.AddDate(0, 0, 21)), "in 3 weeks"},
+		{truncateMonth(now.AddDate(0, 1, 0)), "next month"},
+		{truncateMonth(date(2023, 3, 1, 0, 0, 0)), "March 2023"},
+		{truncateYear(now), "this year"},
+		{truncateYear(date(1999, 1, 1, 0, 0, 0)), "1999 AD"},
+		{truncateHour(date(2022, 9, 29, 17, 0, 0)), "today at 5pm"},
+		{truncateHour(date(2022, 9, 30, 12, 0, 0)), "tomorrow at noon"},
+		{truncateMinute(date(2022, 3, 3, 9, 30, 0)), "March 3, 2022 at 9:30am"},
+		{truncateSecond(date(2022, 9, 28, 0, 0, 15)), "yesterday at 12:00:15am"},
+		{RangeFromTimes(date(2022, 1, 3, 0, 0, 0), date(2022, 2, 20, 0, 0, 0)), "from January 3, 2022 to February 20, 2022"},
+		{RangeFromTimes(date(2022, 9, 29, 9, 0, 0), date(2022, 9, 29, 17, 30, 0)), "from today at 9am to today at 5:30pm"},
+		{RangeFromTimes(now, date(2022, 10, 1, 0, 0, 0)), "from now to October 1, 2022"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := Describe(tt.r, now); got != tt.want {
+				t.Errorf("Describe() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDescribe_otherZone(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 0, time.UTC)
+	r := truncateDay(time.Date(2022, 9, 29, 0, 0, 0, 0, fixedZone(-8)))
+	if got, want := Describe(r, now), "from today at 8am to tomorrow at 8am"; got != want {
+		t.Errorf("Describe() = %q, want %q", got, want)
+	}
+}
+
+func TestDescribe_roundTrip(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	refs := []time.Time{
+		time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC),
+		time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, 3, 31, 23, 59, 59, 0, fixedZone(5)),
+		time.Date(2022, 11, 6, 1, 30, 0, 0, ny),
+		time.Date(2022, 11, 6, 1, 30, 0, 0, ny).Add(time.Hour),
+		time.Date(2022, 3, 13, 12, 0, 0, 0, ny),
+	}
+	for _, ref := range refs {
+		var ranges []Range
+		for i := -40; i <= 40; i++ {
+			ranges = append(ranges,
+				truncateDay(ref.AddDate(0, 0, i)),
+				truncateWeek(ref.AddDate(0, 0, 7*i)),
+				truncateMonth(ref.AddDate(0, i, 0)),
+				truncateYear(ref.AddDate(i, 0, 0)),
+				truncateHour(ref.Add(time.Duration(i)*time.Hour)),
+				truncateMinute(ref.Add(time.Duration(i)*37*time.Minute)),
+				truncateSecond(ref.Add(time.Duration(i)*1001*time.Second)),
+				RangeFromTimes(ref, truncateHour(ref.AddDate(0, 0, i)).start),
+				RangeFromTimes(truncateDay(ref).start, truncateSecond(ref.Add(time.Duration(i)*time.Hour)).start),
+				RangeFromTimes(truncateHour(ref).start.Add(time.Duration(i)*time.Hour), ref),
+			)
+		}
+		for _, r := range ranges {
+			s := Describe(r, ref)
+			for _, dir := range []Direction{Past, Future} {
+				got, parsed, err := ParseRange(s, ref, dir)
+				if err != nil {
+					t.Errorf("ParseRange(%q) with ref %v: %v", s, ref, err)
+					continue
+				}
+				if parsed != s {
+					t.Errorf("ParseRange(%q) with ref %v only parsed %q", s, ref, parsed)
+				}
+				if !got.Equal(r) {
+					t.Errorf("ParseRange(%q) with ref %v = %v, want %v", s, ref, got, r)
+				}
+			}
+		}
+	}
+}
+
+func TestDescribe_dstEnd(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	edt := time.Date(2022, 11, 6, 1, 30, 0, 0, ny)
+	est := edt.Add(time.Hour)
+	tests := []struct {
+		r    Range
+		ref  time.Time
+		want string
+	}{
+		{truncateDay(edt), edt, "today"},
+		{RangeFromTimes(edt, est), edt, "from now to today at 1:30am utc-5"},
+		{RangeFromTimes(edt, est), est, "from today at 1:30am to now"},
+		{truncateHour(est), edt, "today at 1am utc-5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := Describe(tt.r, tt.ref); got != tt.want {
+				t.Errorf("Describe() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
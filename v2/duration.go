@@ -0,0 +1,213 @@
+package anytime
+
+import (
+	"errors"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+var ErrNoDurationFound = errors.New("no duration found")
+
+// Period is a length of time with its calendar part, whose length depends on
+// the date it is applied to, kept separate from its clock part.
+type Period struct {
+	Years, Months, Days int
+
+	// Duration is the clock part of the period, such as "2 hours".
+	Duration time.Duration
+}
+
+// AddTo returns t moved forward by p, using calendar arithmetic for the
+// years, months and days so that "1 month" from january 31 is march 3 or 2
+// as time.AddDate would have it.
+func (p Period) AddTo(t time.Time) time.Time {
+	return t.AddDate(p.Years, p.Months, p.Days).Add(p.Duration)
+}
+
+// approximate returns p as a time.Duration, taking a day to be 24 hours, a
+// month to be 30 days and a year to be 365 days.
+func (p Period) approximate() time.Duration {
+	days := time.Duration(365*p.Years + 30*p.Months + p.Days)
+	return days*24*time.Hour + p.Duration
+}
+
+func (p Period) plus(q Period) Period {
+	return Period{p.Years + q.Years, p.Months + q.Months, p.Days + q.Days, p.Duration + q.Duration}
+}
+
+// times returns p multiplied by x. Fractions are only allowed for clock
+// periods, or if they are halves. The result must fit in a time.Duration
+// when approximated.
+func (p Period) times(x float64) (Period, bool) {
+	if x*float64(p.approximate()) >= math.MaxInt64 {
+		return Period{}, false
+	}
+	n := int(x)
+	r := Period{n * p.Years, n * p.Months, n * p.Days, time.Duration(n) * p.Duration}
+	switch frac := x - float64(n); {
+	case frac == 0:
+		return r, true
+	case p.Years == 0 && p.Months == 0 && p.Days == 0:
+		return r.plus(Period{Duration: time.Duration(frac * float64(p.Duration))}), true
+	case frac == 0.5:
+		h, ok := p.half()
+		return r.plus(h), ok
+	}
+	return Period{}, false
+}
+
+// half returns half of the unit period p, if it can be expressed exactly.
+// Half a day is 12 hours, but there is no exact half of a month.
+func (p Period) half() (Period, bool) {
+	switch {
+	case p.Years != 0:
+		return Period{Months: 6 * p.Years}, true
+	case p.Months != 0:
+		return Period{}, false
+	case p.Days%2 == 1:
+		return Period{Days: p.Days / 2, Duration: p.Duration/2 + 12*time.Hour}, true
+	}
+	return Period{Days: p.Days / 2, Duration: p.Duration / 2}, true
+}
+
+// ParseDuration parses a length of time such as "2 hours and 30 minutes",
+// "an hour and a half", "3d4h", "a fortnight" or "90 mins". Numbers may be
+// written as words such as "two" or as numerals, including decimals like
+// "1.5 hours". The result is returned both as a time.Duration, taking a day
+// to be 24 hours, a month to be 30 days and a year to be 365 days, and as a
+// Period that can be applied to a date with calendar arithmetic. Lengths
+// too long for a time.Duration are not accepted.
+func ParseDuration(s string) (time.Duration, Period, error) {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return unicode.IsSpace(r) || r == ','
+	})
+	var p, last Period
+	terms := 0
+
+	// total is the approximate length of p, kept as a float so that it can
+	// be checked for overflow.
+	var total float64
+	add := func(q Period) {
+		p = p.plus(q)
+		total += float64(q.approximate())
+	}
+	for i := 0; i < len(words); {
+		w := words[i]
+		rest := words[i+1:]
+		switch {
+		case w == "and" && terms > 0 && len(rest) >= 2 && (rest[0] == "a" || rest[0] == "an") && rest[1] == "half":
+			// "an hour and a half"
+			h, ok := last.half()
+			if !ok {
+				return 0, Period{}, ErrNoDurationFound
+			}
+			add(h)
+			i += 3
+			continue
+		case w == "and" && terms > 0 && len(rest) > 0:
+			i++
+			continue
+		case w == "half" && len(rest) >= 2 && (rest[0] == "a" || rest[0] == "an"):
+			// "half an hour"
+			u, ok := durationUnits[rest[1]]
+			h, ok2 := u.half()
+			if !ok || !ok2 {
+				return 0, Period{}, ErrNoDurationFound
+			}
+			add(h)
+			last = u
+			i += 3
+		case compactDurationRx.MatchString(w):
+			// "3d4h"
+			for _, sm := range compactDurationPartRx.FindAllStringSubmatch(w, -1) {
+				x, _ := strconv.ParseFloat(sm[1], 64)
+				u, ok := durationUnits[sm[2]]
+				q, ok2 := u.times(x)
+				if !ok || !ok2 {
+					return 0, Period{}, ErrNoDurationFound
+				}
+				add(q)
+				last = u
+			}
+			i++
+		default:
+			// "90 mins", "a fortnight"
+			x, ok := parseDurationAmount(w)
+			if !ok || len(rest) == 0 {
+				return 0, Period{}, ErrNoDurationFound
+			}
+			u, ok := durationUnits[rest[0]]
+			q, ok2 := u.times(x)
+			if !ok || !ok2 {
+				return 0, Period{}, ErrNoDurationFound
+			}
+			add(q)
+			last = u
+			i += 2
+		}
+		terms++
+	}
+	if terms == 0 || total >= math.MaxInt64 {
+		return 0, Period{}, ErrNoDurationFound
+	}
+	return p.approximate(), p, nil
+}
+
+// parseDurationAmount parses a number of units from the word w, such as
+// "two", "90" or "1.5".
+func parseDurationAmount(w string) (float64, bool) {
+	if i, ok := strToInt[w]; ok {
+		return float64(i), true
+	}
+	if !decimalRx.MatchString(w) {
+		return 0, false
+	}
+	x, err := strconv.ParseFloat(w, 64)
+	return x, err == nil
+}
+
+var decimalRx = regexp.MustCompile(`^\d+(\.\d+)?$`)
+var compactDurationRx = regexp.MustCompile(`^(\d+(\.\d+)?[a-z]+)+$`)
+var compactDurationPartRx = regexp.MustCompile(`(\d+(?:\.\d+)?)([a-z]+)`)
+
+// durationUnits maps the names of units of time to the period of one unit.
+var durationUnits = map[string]Period{
+	"y":          {Years: 1},
+	"yr":         {Years: 1},
+	"yrs":        {Years: 1},
+	"year":       {Years: 1},
+	"years":      {Years: 1},
+	"mo":         {Months: 1},
+	"mos":        {Months: 1},
+	"month":      {Months: 1},
+	"months":     {Months: 1},
+	"fortnight":  {Days: 14},
+	"fortnights": {Days: 14},
+	"w":          {Days: 7},
+	"wk":         {Days: 7},
+	"wks":        {Days: 7},
+	"week":       {Days: 7},
+	"weeks":      {Days: 7},
+	"d":          {Days: 1},
+	"day":        {Days: 1},
+	"days":       {Days: 1},
+	"h":          {Duration: time.Hour},
+	"hr":         {Duration: time.Hour},
+	"hrs":        {Duration: time.Hour},
+	"hour":       {Duration: time.Hour},
+	"hours":      {Duration: time.Hour},
+	"m":          {Duration: time.Minute},
+	"min":        {Duration: time.Minute},
+	"mins":       {Duration: time.Minute},
+	"minute":     {Duration: time.Minute},
+	"minutes":    {Duration: time.Minute},
+	"s":          {Duration: time.Second},
+	"sec":        {Duration: time.Second},
+	"secs":       {Duration: time.Second},
+	"second":     {Duration: time.Second},
+	"seconds":    {Duration: time.Second},
+}
@@ -0,0 +1,81 @@
+package anytime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		input      string
+		wantDur    time.Duration
+		wantPeriod Period
+	}{
+		{"2 hours and 30 minutes", 150 * time.Minute, Period{Duration: 150 * time.Minute}},
+		{"2 hours, 30 minutes", 150 * time.Minute, Period{Duration: 150 * time.Minute}},
+		{"an hour and a half", 90 * time.Minute, Period{Duration: 90 * time.Minute}},
+		{"two hours and a half", 150 * time.Minute, Period{Duration: 150 * time.Minute}},
+		{"half an hour", 30 * time.Minute, Period{Duration: 30 * time.Minute}},
+		{"1.5 hours", 90 * time.Minute, Period{Duration: 90 * time.Minute}},
+		{"3d4h", 76 * time.Hour, Period{Days: 3, Duration: 4 * time.Hour}},
+		{"1h30m", 90 * time.Minute, Period{Duration: 90 * time.Minute}},
+		{"a fortnight", 14 * 24 * time.Hour, Period{Days: 14}},
+		{"90 mins", 90 * time.Minute, Period{Duration: 90 * time.Minute}},
+		{"Twenty Seconds", 20 * time.Second, Period{Duration: 20 * time.Second}},
+		{"a day and a half", 36 * time.Hour, Period{Days: 1, Duration: 12 * time.Hour}},
+		{"half a year", 180 * 24 * time.Hour, Period{Months: 6}},
+		{"1 month", 30 * 24 * time.Hour, Period{Months: 1}},
+		{"2 years 3 months and 1 week", (730 + 90 + 7) * 24 * time.Hour, Period{Years: 2, Months: 3, Days: 7}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			gotDur, gotPeriod, err := ParseDuration(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if gotDur != tt.wantDur {
+				t.Errorf("duration = %v, want %v", gotDur, tt.wantDur)
+			}
+			if gotPeriod != tt.wantPeriod {
+				t.Errorf("period = %+v, want %+v", gotPeriod, tt.wantPeriod)
+			}
+		})
+	}
+}
+
+func TestParseDuration_Errors(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"hours",
+		"and 2 hours",
+		"2 parsecs",
+		"1.5 months",
+		"a month and a half",
+		"-3 days",
+		"3 days from now",
+		"2 hours and",
+		"1e300 hours",
+		"1e3 hours",
+		"300 years",
+		"2000000 hours and 2000000 hours",
+		"99999999999999999999h",
+	} {
+		t.Run(input, func(t *testing.T) {
+			if _, _, err := ParseDuration(input); err != ErrNoDurationFound {
+				t.Errorf("err = %v, want %v", err, ErrNoDurationFound)
+			}
+		})
+	}
+}
+
+func TestPeriod_AddTo(t *testing.T) {
+	_, p, err := ParseDuration("1 month and 2 hours")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := p.AddTo(time.Date(2022, 1, 31, 0, 0, 0, 0, time.UTC))
+	want := time.Date(2022, 3, 3, 2, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Errorf("AddTo() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,112 @@
+package anytime
+
+import (
+	"time"
+)
+
+// Kind is the kind of expression that a Match was parsed from.
+type Kind int
+
+const (
+	// Relative is for expressions such as "yesterday", "3 days ago",
+	// "friday" or "december 20" whose meaning depends on the reference time.
+	Relative Kind = iota
+
+	// Absolute is for expressions such as "march 2023", "2014/3/31" or
+	// "2006-01-02T15:04:05Z" that mean the same thing whatever the
+	// reference time.
+	Absolute
+
+	// ExplicitRange is for expressions such as "from april to may" or
+	// "3 feb 2022 - 6 oct 2022" that give both ends of a range.
+	ExplicitRange
+
+	// ColorMonth is for futures-market color months such as "red october".
+	ColorMonth
+)
+
+// String returns the name of the kind.
+func (k Kind) String() string {
+	switch k {
+	case Relative:
+		return "relative"
+	case Absolute:
+		return "absolute"
+	case ExplicitRange:
+		return "explicit range"
+	case ColorMonth:
+		return "color month"
+	}
+	return "unknown"
+}
+
+// Match is a date or range found within a string by FindAllRanges.
+type Match struct {
+	// Start is the byte offset of the beginning of Src within the string
+	// that was searched.
+	Start int
+
+	// End is the byte offset just past the end of Src within the string
+	// that was searched.
+	End int
+
+	// Src is the text that was parsed to get Range.
+	Src string
+
+	// Range is the range that Src refers to.
+	Range Range
+
+	// Kind is the kind of expression that Src is.
+	Kind Kind
+}
+
+// FindAll returns all the dates and date ranges within the string s, in the
+// order they appear. The now and options arguments are the same as for
+// Parse.
+func FindAll(s string, now time.Time, options ...func(o *opts)) []Match {
+	o := newOpts(options...)
+	var matches []Match
+	p := 0
+	for p < len(s) {
+		// sofw is the start of the first word.
+		sofw := findNextSignal(s, p)
+		r, parsed, kind, err := parseRange(s[sofw:], now, o)
+		if err == ErrAmbiguousDate {
+			// Skip the whole date, so that its year is not found on its
+			// own, as in "03.04.2022".
+			if nd := numericDateRx.FindString(s[sofw:]); nd != "" {
+				p = sofw + len(nd)
+				continue
+			}
+		}
+		if err == ErrNoSuchDay {
+			// Skip the whole expression, so that "monday" is not found
+			// on its own in "fifth monday of february".
+			if _, eod, _, _ := parseOrdinalDay(s, sofw, now, o); eod > sofw {
+				p = eod
+				continue
+			}
+		}
+		if err != nil {
+			// eofw is the end of the first word.
+			eofw := findNextNoise(s, sofw)
+			p = eofw
+			continue
+		}
+		p = sofw + len(parsed)
+		matches = append(matches, Match{
+			Start: sofw,
+			End:   p,
+			Src:   parsed,
+			Range: r,
+			Kind:  kind,
+		})
+	}
+	return matches
+}
+
+// FindAllRanges is like FindAll, choosing the instance of ambiguous dates
+// given by dir.
+func FindAllRanges(s string, now time.Time, dir Direction) []Match {
+	return FindAll(s, now, WithDirection(dir))
+}
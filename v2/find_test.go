@@ -0,0 +1,101 @@
+package anytime
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFindAllRanges(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	tests := []struct {
+		name  string
+		input string
+		want  []Match
+	}{
+		{
+			name:  "empty",
+			input: "",
+			want:  nil,
+		},
+		{
+			name:  "no ranges",
+			input: "nothing to see here",
+			want:  nil,
+		},
+		{
+			name:  "relative",
+			input: "Shipped yesterday.",
+			want: []Match{
+				{Start: 8, End: 17, Src: "yesterday", Range: truncateDay(now.AddDate(0, 0, -1)), Kind: Relative},
+			},
+		},
+		{
+			name:  "absolute after multibyte text",
+			input: "😅 due March 2023, maybe",
+			want: []Match{
+				{Start: 9, End: 19, Src: "March 2023", Range: truncateMonth(time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)), Kind: Absolute},
+			},
+		},
+		{
+			name:  "explicit range and color month",
+			input: "from april to may, then red october",
+			want: []Match{
+				{
+					Start: 0,
+					End:   17,
+					Src:   "from april to may",
+					Range: withGranularity(RangeFromTimes(time.Date(2023, 4, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)), Month),
+					Kind:  ExplicitRange,
+				},
+				{
+					Start: 24,
+					End:   35,
+					Src:   "red october",
+					Range: truncateMonth(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)),
+					Kind:  ColorMonth,
+				},
+			},
+		},
+		{
+			name:  "several",
+			input: "2006-01-02T15:04:05Z, 5pm and 1999 AD",
+			want: []Match{
+				{Start: 0, End: 20, Src: "2006-01-02T15:04:05Z", Range: Range{time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC), time.Second, Second}, Kind: Absolute},
+				{Start: 22, End: 25, Src: "5pm", Range: Range{time.Date(2022, 9, 29, 17, 0, 0, 0, time.UTC), time.Hour, Hour}, Kind: Relative},
+				{Start: 30, End: 37, Src: "1999 AD", Range: truncateYear(time.Date(1999, 1, 1, 0, 0, 0, 0, time.UTC)), Kind: Absolute},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FindAllRanges(tt.input, now, Future)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindAllRanges() =\n%+v\nwant\n%+v", got, tt.want)
+			}
+			for _, m := range got {
+				if tt.input[m.Start:m.End] != m.Src {
+					t.Errorf("input[%d:%d] = %q, want %q", m.Start, m.End, tt.input[m.Start:m.End], m.Src)
+				}
+			}
+		})
+	}
+}
+
+func TestKind_String(t *testing.T) {
+	tests := []struct {
+		kind Kind
+		want string
+	}{
+		{Relative, "relative"},
+		{Absolute, "absolute"},
+		{ExplicitRange, "explicit range"},
+		{ColorMonth, "color month"},
+		{Kind(-1), "unknown"},
+	}
+	for _, tt := range tests {
+		if got := tt.kind.String(); got != tt.want {
+			t.Errorf("Kind(%d).String() = %q, want %q", tt.kind, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,151 @@
+package anytime
+
+import (
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// quarterOrHalfRx matches words like "q3" and "h1" that name a quarter or a
+// half of a year.
+var quarterOrHalfRx = regexp.MustCompile(`^([qh])([1-4])$`)
+
+// fiscalYearRx matches words like "fy2024", "fy24" and "fy-24" that name a
+// fiscal year, and "fy" on its own for when the year is a separate word.
+var fiscalYearRx = regexp.MustCompile(`^fy-?(\d{4}|\d{2})?$`)
+
+// period is a quarter or a half of a year.
+type period struct {
+	// n is the number of the period within the year, starting from 1.
+	n int
+
+	// u is quarterUnit or halfUnit.
+	u unit
+}
+
+// parsePeriod parses a quarter or half of a year, as in "q3" or "h1", from the
+// lower-cased word w.
+func parsePeriod(w string) (period, bool) {
+	m := quarterOrHalfRx.FindStringSubmatch(w)
+	if m == nil {
+		return period{}, false
+	}
+	n, _ := strconv.Atoi(m[2])
+	if m[1] == "h" {
+		if n > 2 {
+			return period{}, false
+		}
+		return period{n, halfUnit}, true
+	}
+	return period{n, quarterUnit}, true
+}
+
+// in returns the range of the period within the year starting at the time
+// yearStart.
+func (p period) in(yearStart time.Time) Range {
+	s := p.u.add(yearStart, p.n-1)
+	return Range{s, p.u.add(s, 1).Sub(s), p.u.granularity}
+}
+
+// parseYear parses a four-digit year from the word w.
+func parseYear(w string) (int, bool) {
+	if len(w) != 4 {
+		return 0, false
+	}
+	y, err := strconv.Atoi(w)
+	if err != nil || y < 1000 || y > 9999 {
+		return 0, false
+	}
+	return y, true
+}
+
+// parseFiscalYear parses a fiscal year, as in "fy2024", "fy24" or "fy 2024",
+// starting at the first word at or after index start of s. It returns the
+// year that names the fiscal year and the index just past it.
+func parseFiscalYear(s string, start int) (int, int, bool) {
+	_, eow, w := findSignalNoise(s, start)
+	m := fiscalYearRx.FindStringSubmatch(w)
+	if m == nil {
+		return 0, 0, false
+	}
+	digits := m[1]
+	if digits == "" {
+		_, eoy, yw := findSignalNoise(s, eow)
+		if _, ok := parseYear(yw); !ok {
+			return 0, 0, false
+		}
+		digits, eow = yw, eoy
+	}
+	y, _ := strconv.Atoi(digits)
+	if len(digits) == 2 {
+		y += 2000
+	}
+	return y, eow, true
+}
+
+// startOfFiscalYear returns the start of the fiscal year named y, with fiscal
+// years starting in the month first. Fiscal years are named after the
+// calendar year in which they end, so with fiscal years starting in October,
+// FY2024 runs from October 2023 to September 2024.
+func startOfFiscalYear(y int, first time.Month, loc *time.Location) time.Time {
+	if first != time.January {
+		y--
+	}
+	return time.Date(y, first, 1, 0, 0, 0, 0, loc)
+}
+
+// parseQuarter parses a quarter or half of a year, as in "q3", "q4 2021",
+// "2023 h2", "fy23 q2" or "q2 fy23", or a fiscal year, as in "fy2024", at the
+// first word at or after index start of s. It returns the range, the index just
+// past it and the kind of expression it is. Quarters and halves of years given
+// as fiscal years are fiscal quarters and halves, and the others are calendar
+// quarters and halves.
+func parseQuarter(s string, start int, now time.Time, o opts) (Range, int, Kind, bool) {
+	loc := now.Location()
+	sow, eow, w := findSignalNoise(s, start)
+
+	// Fiscal year, possibly followed by a quarter or half of it
+	if y, eofy, ok := parseFiscalYear(s, sow); ok {
+		fy := startOfFiscalYear(y, o.fiscalYearStart, loc)
+		_, eop, pw := findSignalNoise(s, eofy)
+		if p, ok := parsePeriod(pw); ok {
+			return p.in(fy), eop, Absolute, true
+		}
+		return truncateFiscalYear(fy, o.fiscalYearStart), eofy, Absolute, true
+	}
+
+	// Year followed by a quarter or half, as in "2023 h2"
+	if y, ok := parseYear(w); ok {
+		_, eop, pw := findSignalNoise(s, eow)
+		if p, ok := parsePeriod(pw); ok {
+			return p.in(time.Date(y, 1, 1, 0, 0, 0, 0, loc)), eop, Absolute, true
+		}
+		return Range{}, 0, 0, false
+	}
+
+	p, ok := parsePeriod(w)
+	if !ok {
+		return Range{}, 0, 0, false
+	}
+
+	// Quarter or half followed by a fiscal year or a year
+	if y, eofy, ok := parseFiscalYear(s, eow); ok {
+		return p.in(startOfFiscalYear(y, o.fiscalYearStart, loc)), eofy, Absolute, true
+	}
+	_, eoy, yw := findSignalNoise(s, eow)
+	if y, ok := parseYear(yw); ok {
+		return p.in(time.Date(y, 1, 1, 0, 0, 0, 0, loc)), eoy, Absolute, true
+	}
+
+	// Quarter or half on its own, taken to be the next one or the last one,
+	// as with month names.
+	cur := p.u.truncate(now)
+	r := p.in(time.Date(now.Year(), 1, 1, 0, 0, 0, 0, loc))
+	if o.dir == Future && !r.start.After(cur.start) {
+		r = p.in(time.Date(now.Year()+1, 1, 1, 0, 0, 0, 0, loc))
+	}
+	if o.dir == Past && !r.start.Before(cur.start) {
+		r = p.in(time.Date(now.Year()-1, 1, 1, 0, 0, 0, 0, loc))
+	}
+	return r, eow, Relative, true
+}
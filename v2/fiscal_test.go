@@ -0,0 +1,66 @@
+package anytime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_quarters(t *testing.T) {
+	quarter := func(y int, m time.Month) Range {
+		return truncateQuarter(time.Date(y, m, 1, 0, 0, 0, 0, time.UTC))
+	}
+	half := func(y int, m time.Month) Range {
+		return truncateHalf(time.Date(y, m, 1, 0, 0, 0, 0, time.UTC))
+	}
+	months := func(y int, m time.Month, n int) Range {
+		s := time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+		return Range{s, s.AddDate(0, n, 0).Sub(s), Quarter}
+	}
+	testParse(t, []parseTest{
+		{"Q3 2022", nil, quarter(2022, 7)},
+		{"q4 2021", nil, quarter(2021, 10)},
+		{"2023 Q1", nil, quarter(2023, 1)},
+		{"Q4", nil, quarter(2022, 10)},
+		{"Q3", nil, quarter(2023, 7)},
+		{"Q3", []func(o *opts){DefaultToPast}, quarter(2021, 7)},
+		{"Q1", []func(o *opts){DefaultToPast}, quarter(2022, 1)},
+		{"last quarter", nil, quarter(2022, 4)},
+		{"this quarter", nil, quarter(2022, 7)},
+		{"next quarter", nil, quarter(2022, 10)},
+		{"in 2 quarters", nil, quarter(2023, 1)},
+		{"H1", nil, half(2023, 1)},
+		{"H2 2023", nil, half(2023, 7)},
+		{"h1", []func(o *opts){DefaultToPast}, half(2022, 1)},
+		{"FY2024", nil, truncateYear(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))},
+		{"FY 2024", nil, truncateYear(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))},
+		{"FY24", []func(o *opts){FiscalYearStartsIn(time.October)}, truncateFiscalYear(time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC), time.October)},
+		{"FY23 Q2", nil, quarter(2023, 4)},
+		{"FY23 Q2", []func(o *opts){FiscalYearStartsIn(time.July)}, months(2022, 10, 3)},
+		{"Q1 FY2023", []func(o *opts){FiscalYearStartsIn(time.April)}, months(2022, 4, 3)},
+		{"FY23 H2", []func(o *opts){FiscalYearStartsIn(time.July)}, Range{time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC).Sub(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)), Half}},
+		{"this fy", []func(o *opts){FiscalYearStartsIn(time.July)}, truncateFiscalYear(time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC), time.July)},
+		{"last fy", []func(o *opts){FiscalYearStartsIn(time.October)}, truncateFiscalYear(time.Date(2020, 10, 1, 0, 0, 0, 0, time.UTC), time.October)},
+	})
+}
+
+func TestParse_quartersFail(t *testing.T) {
+	testParseFail(t, []string{"H3", "Q5", "Q0", "FY", "FY 22 Q5"})
+}
+
+func Test_truncateFiscalYear(t *testing.T) {
+	tests := []struct {
+		t     time.Time
+		first time.Month
+		want  time.Time
+	}{
+		{time.Date(2022, 9, 29, 2, 48, 33, 0, time.UTC), time.January, time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2022, 9, 29, 2, 48, 33, 0, time.UTC), time.October, time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC), time.October, time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got := truncateFiscalYear(tt.t, tt.first)
+		if !got.Start().Equal(tt.want) || !got.End().Equal(tt.want.AddDate(1, 0, 0)) || got.Granularity != Year {
+			t.Errorf("truncateFiscalYear(%v, %v) = %v, want a year from %v", tt.t, tt.first, got, tt.want)
+		}
+	}
+}
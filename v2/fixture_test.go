@@ -0,0 +1,66 @@
+package anytime
+
+import (
+	"testing"
+	"time"
+)
+
+// testNow is the time that the table tests parse relative to, a Thursday in
+// Q3, H2 and ISO week 39 of 2022.
+var testNow = time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+
+// testDays returns the n days in UTC starting on the day y-m-d.
+func testDays(y int, m time.Month, d, n int) Range {
+	return Range{time.Date(y, m, d, 0, 0, 0, 0, time.UTC), time.Duration(n) * 24 * time.Hour, Day}
+}
+
+// testDay returns the day y-m-d in UTC.
+func testDay(y int, m time.Month, d int) Range {
+	return testDays(y, m, d, 1)
+}
+
+// testHours returns the n hours in UTC starting at hour h of the day y-m-d.
+func testHours(y int, m time.Month, d, h, n int) Range {
+	return Range{time.Date(y, m, d, h, 0, 0, 0, time.UTC), time.Duration(n) * time.Hour, Hour}
+}
+
+// parseTest is a case for testParse.
+type parseTest struct {
+	input   string
+	options []func(o *opts)
+	want    Range
+}
+
+// testParse checks that Parse reads all of the input of each test as its
+// range, relative to testNow and with its options.
+func testParse(t *testing.T, tests []parseTest) {
+	t.Helper()
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, parsed, err := Parse(tt.input, testNow, tt.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) || got.Granularity != tt.want.Granularity {
+				t.Errorf("got %v %v, want %v %v", got, got.Granularity, tt.want, tt.want.Granularity)
+			}
+			if parsed != tt.input {
+				t.Errorf("parsed %q, want %q", parsed, tt.input)
+			}
+		})
+	}
+}
+
+// testParseFail checks that Parse does not read all of any of the inputs,
+// relative to testNow.
+func testParseFail(t *testing.T, inputs []string) {
+	t.Helper()
+	for _, s := range inputs {
+		t.Run(s, func(t *testing.T) {
+			got, parsed, err := Parse(s, testNow)
+			if err == nil && parsed == s {
+				t.Errorf("got %v, want error", got)
+			}
+		})
+	}
+}
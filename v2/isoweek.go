@@ -0,0 +1,144 @@
+package anytime
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// isoWeekRx matches ISO 8601 week dates such as "2022-w05", "2022w05",
+// "2022-w05-3" and "2022w053", in lower case.
+var isoWeekRx = regexp.MustCompile(`^(\d{4})-?w(\d{2})(?:-?([1-7]))?$`)
+
+// ordinalDateRx matches ISO 8601 ordinal dates such as "2022-256".
+var ordinalDateRx = regexp.MustCompile(`^(\d{4})-(\d{3})$`)
+
+// kwRx matches words like "kw17" that name a week by its number.
+var kwRx = regexp.MustCompile(`^(?:kw|wk)(\d{1,2})$`)
+
+// weekWords are the words that may come before a week number, as in
+// "week 42" or "KW 17".
+var weekWords = map[string]bool{
+	"week": true,
+	"wk":   true,
+	"kw":   true,
+}
+
+// isoWeekStart returns the Monday that starts week w of the ISO 8601
+// week-numbering year y, in loc. Week 1 is the week containing January 4.
+func isoWeekStart(y, w int, loc *time.Location) time.Time {
+	jan4 := time.Date(y, 1, 4, 0, 0, 0, 0, loc)
+	sinceMonday := (int(jan4.Weekday()) + 6) % 7
+	return jan4.AddDate(0, 0, 7*(w-1)-sinceMonday)
+}
+
+// isoWeeksIn returns the number of weeks, 52 or 53, in the ISO 8601
+// week-numbering year y.
+func isoWeeksIn(y int) int {
+	_, w := time.Date(y, 12, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return w
+}
+
+// isoWeek returns the range of week w of the ISO 8601 week-numbering year y,
+// or false if there is no such week.
+func isoWeek(y, w int, loc *time.Location) (Range, bool) {
+	if w < 1 || w > isoWeeksIn(y) {
+		return Range{}, false
+	}
+	s := isoWeekStart(y, w, loc)
+	return Range{s, s.AddDate(0, 0, 7).Sub(s), Week}, true
+}
+
+// parseWeekDate parses an ISO 8601 week date or ordinal date, as in
+// "2022-W05", "2022W05-3" or "2022-256", or a week number, as in "week 42
+// 2023" or "KW 17", at the first word at or after index start of s. It
+// returns the range, the index just past it and the kind of expression it is.
+// Week numbers without a year are taken to be the next or the last such week,
+// as with month names.
+func parseWeekDate(s string, start int, now time.Time, o opts) (Range, int, Kind, bool) {
+	loc := now.Location()
+	_, eow, w := findSignalNoise(s, start)
+
+	// Week date, as in "2022-w05" or "2022-w05-3"
+	if m := isoWeekRx.FindStringSubmatch(w); m != nil {
+		y, _ := strconv.Atoi(m[1])
+		wk, _ := strconv.Atoi(m[2])
+		r, ok := isoWeek(y, wk, loc)
+		if !ok {
+			return Range{}, 0, 0, false
+		}
+		if m[3] != "" {
+			d, _ := strconv.Atoi(m[3])
+			r = truncateDay(r.start.AddDate(0, 0, d-1))
+		}
+		return r, eow, Absolute, true
+	}
+
+	// Ordinal date, as in "2022-256"
+	if m := ordinalDateRx.FindStringSubmatch(w); m != nil {
+		y, _ := strconv.Atoi(m[1])
+		d, _ := strconv.Atoi(m[2])
+		jan1 := time.Date(y, 1, 1, 0, 0, 0, 0, loc)
+		if d < 1 || d > jan1.AddDate(1, 0, -1).YearDay() {
+			return Range{}, 0, 0, false
+		}
+		return truncateDay(jan1.AddDate(0, 0, d-1)), eow, Absolute, true
+	}
+
+	// Week number, as in "week 42 2023", "kw 17" or "kw17"
+	var wk int
+	if m := kwRx.FindStringSubmatch(w); m != nil {
+		wk, _ = strconv.Atoi(m[1])
+	} else if weekWords[w] {
+		_, eon, n := findSignalNoise(s, eow)
+		i, err := strconv.Atoi(n)
+		if err != nil {
+			return Range{}, 0, 0, false
+		}
+		wk, eow = i, eon
+	} else {
+		return Range{}, 0, 0, false
+	}
+	_, eoy, yw := findSignalNoise(s, eow)
+	if y, ok := parseYear(yw); ok {
+		r, ok := isoWeek(y, wk, loc)
+		if !ok {
+			return Range{}, 0, 0, false
+		}
+		return r, eoy, Absolute, true
+	}
+	y, cw := now.ISOWeek()
+	cur, _ := isoWeek(y, cw, loc)
+	r, ok := isoWeek(y, wk, loc)
+	switch {
+	case o.dir == Future && (!ok || !r.start.After(cur.start)):
+		r, ok = isoWeek(y+1, wk, loc)
+	case o.dir == Past && (!ok || !r.start.Before(cur.start)):
+		r, ok = isoWeek(y-1, wk, loc)
+	}
+	if !ok {
+		return Range{}, 0, 0, false
+	}
+	return r, eow, Relative, true
+}
+
+// FormatISOWeek returns the ISO 8601 week containing the start of r, as in
+// "2022-W05".
+func (r Range) FormatISOWeek() string {
+	y, w := r.start.ISOWeek()
+	return fmt.Sprintf("%04d-W%02d", y, w)
+}
+
+// FormatISOWeekDate returns the start of r as an ISO 8601 week date, as in
+// "2022-W05-3" for the Wednesday of week 5 of 2022.
+func (r Range) FormatISOWeekDate() string {
+	wd := (int(r.start.Weekday())+6)%7 + 1
+	return fmt.Sprintf("%s-%d", r.FormatISOWeek(), wd)
+}
+
+// FormatOrdinalDate returns the start of r as an ISO 8601 ordinal date, as in
+// "2022-256".
+func (r Range) FormatOrdinalDate() string {
+	return fmt.Sprintf("%04d-%03d", r.start.Year(), r.start.YearDay())
+}
@@ -0,0 +1,76 @@
+package anytime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_weekDates(t *testing.T) {
+	week := func(y int, m time.Month, d int) Range {
+		return truncateWeekFrom(time.Date(y, m, d, 0, 0, 0, 0, time.UTC), time.Monday)
+	}
+	testParse(t, []parseTest{
+		{"2022-W05", nil, week(2022, 1, 31)},
+		{"2022W05", nil, week(2022, 1, 31)},
+		{"2022-W05-3", nil, testDay(2022, 2, 2)},
+		{"2022W05-3", nil, testDay(2022, 2, 2)},
+		{"2022W053", nil, testDay(2022, 2, 2)},
+		{"2021-W01", nil, week(2021, 1, 4)},
+		{"2020-W53", nil, week(2020, 12, 28)},
+		{"2020-W53-7", nil, testDay(2021, 1, 3)},
+		{"2019-W01-1", nil, testDay(2018, 12, 31)},
+		{"week 42 2023", nil, week(2023, 10, 16)},
+		{"Week 1 2021", nil, week(2021, 1, 4)},
+		{"KW 17", nil, week(2023, 4, 24)},
+		{"KW17", nil, week(2023, 4, 24)},
+		{"kw 17", []func(o *opts){DefaultToPast}, week(2022, 4, 25)},
+		{"week 40", nil, week(2022, 10, 3)},
+		{"week 39", []func(o *opts){DefaultToPast}, week(2021, 9, 27)},
+		{"2022-256", nil, testDay(2022, 9, 13)},
+		{"2020-366", nil, testDay(2020, 12, 31)},
+		{"2022-001", nil, testDay(2022, 1, 1)},
+	})
+}
+
+func TestParse_weekDatesFail(t *testing.T) {
+	testParseFail(t, []string{
+		// Week 53 of years with 52 weeks
+		"2022-W53", "2021-W53", "2021-W53-1", "week 53 2021",
+		// Day 366 of years that are not leap years
+		"2022-366", "2021-366", "1900-366",
+		"2022-W00", "2022-W05-8", "2022-000", "week 54 2022",
+	})
+}
+
+func TestRange_formatWeekDates(t *testing.T) {
+	tests := []struct {
+		r               Range
+		wantWeek        string
+		wantWeekDate    string
+		wantOrdinalDate string
+	}{
+		{truncateDay(time.Date(2022, 2, 2, 0, 0, 0, 0, time.UTC)), "2022-W05", "2022-W05-3", "2022-033"},
+		{truncateDay(time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)), "2020-W53", "2020-W53-7", "2021-003"},
+		{truncateDay(time.Date(2018, 12, 31, 0, 0, 0, 0, time.UTC)), "2019-W01", "2019-W01-1", "2018-365"},
+		{truncateDay(time.Date(2022, 9, 13, 0, 0, 0, 0, time.UTC)), "2022-W37", "2022-W37-2", "2022-256"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.wantWeekDate, func(t *testing.T) {
+			if got := tt.r.FormatISOWeek(); got != tt.wantWeek {
+				t.Errorf("FormatISOWeek() = %q, want %q", got, tt.wantWeek)
+			}
+			if got := tt.r.FormatISOWeekDate(); got != tt.wantWeekDate {
+				t.Errorf("FormatISOWeekDate() = %q, want %q", got, tt.wantWeekDate)
+			}
+			if got := tt.r.FormatOrdinalDate(); got != tt.wantOrdinalDate {
+				t.Errorf("FormatOrdinalDate() = %q, want %q", got, tt.wantOrdinalDate)
+			}
+			// The formatted dates parse back to the same day.
+			for _, s := range []string{tt.wantWeekDate, tt.wantOrdinalDate} {
+				if r, _, err := Parse(s, testNow); err != nil || !r.Equal(tt.r) {
+					t.Errorf("Parse(%q) = %v, %v, want %v", s, r, err, tt.r)
+				}
+			}
+		})
+	}
+}
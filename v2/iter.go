@@ -0,0 +1,32 @@
+//go:build go1.23
+
+package anytime
+
+import "iter"
+
+// Steps returns an iterator over the pieces that Split would return.
+func (r Range) Steps(step Granularity, options ...func(o *opts)) iter.Seq[Range] {
+	o := newOpts(options...)
+	return func(yield func(Range) bool) {
+		r.split(step, o, yield)
+	}
+}
+
+// All returns an iterator over the disjoint ranges making up s, in order.
+func (s RangeSet) All() iter.Seq[Range] {
+	return func(yield func(Range) bool) {
+		for _, r := range s.ranges {
+			if !yield(r) {
+				return
+			}
+		}
+	}
+}
+
+// Occurrences returns an iterator over the occurrences that Between would
+// return.
+func (rec Recurrence) Occurrences(r Range) iter.Seq[Range] {
+	return func(yield func(Range) bool) {
+		rec.between(r, yield)
+	}
+}
@@ -0,0 +1,55 @@
+//go:build go1.23
+
+package anytime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRange_Steps(t *testing.T) {
+	r := truncateMonth(time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC))
+	var got []Range
+	for d := range r.Steps(Day) {
+		got = append(got, d)
+		if len(got) == 3 {
+			break
+		}
+	}
+	want := r.Days()[:3]
+	for i := range want {
+		if !got[i].Equal(want[i]) {
+			t.Errorf("piece %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestRangeSet_All(t *testing.T) {
+	s := NewRangeSet(hours(5, 6), hours(1, 2))
+	var got []Range
+	for r := range s.All() {
+		got = append(got, r)
+	}
+	if len(got) != 2 || !got[0].Equal(hours(1, 2)) || !got[1].Equal(hours(5, 6)) {
+		t.Errorf("All() yielded %v, want %v", got, s.Ranges())
+	}
+}
+
+func TestRecurrence_Occurrences(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	rec, _, err := ParseRecurrence("every day at noon", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []Range
+	for o := range rec.Occurrences(truncateMonth(now)) {
+		got = append(got, o)
+		if len(got) == 2 {
+			break
+		}
+	}
+	want := rec.Between(truncateMonth(now))[:2]
+	if len(got) != 2 || !got[0].Equal(want[0]) || !got[1].Equal(want[1]) {
+		t.Errorf("Occurrences() yielded %v, want %v", got, want)
+	}
+}
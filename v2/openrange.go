@@ -0,0 +1,181 @@
+package anytime
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNoBoundFound is returned when a word like "since" or "before" is not
+// followed by a range.
+var ErrNoBoundFound = errors.New("no range found just after `since`, `before` or similar")
+
+// Bound is how an OpenRange is bounded at one of its ends.
+type Bound int
+
+const (
+	// Unbounded means that the range goes on forever at that end.
+	Unbounded Bound = iota
+
+	// Inclusive means that the range includes the range bounding it at that
+	// end, as "since march" includes March and "until friday" includes
+	// Friday.
+	Inclusive
+
+	// Exclusive means that the range stops short of the range bounding it at
+	// that end, as "after march" starts on April 1 and "before 2020" ends
+	// when 2019 does.
+	Exclusive
+)
+
+// OpenRange is a range of times that may have no start or no end, as in
+// "since march" or "before 2020". The zero value is unbounded at both ends
+// and so contains all times.
+type OpenRange struct {
+	// From is the range bounding the start, unless StartBound is Unbounded.
+	From       Range
+	StartBound Bound
+
+	// To is the range bounding the end, unless EndBound is Unbounded.
+	To       Range
+	EndBound Bound
+}
+
+// Open returns r as an OpenRange bounded inclusively by r at both ends.
+func (r Range) Open() OpenRange {
+	return OpenRange{r, Inclusive, r, Inclusive}
+}
+
+// Start returns when r begins, inclusive, or false if it has no start.
+func (r OpenRange) Start() (time.Time, bool) {
+	switch r.StartBound {
+	case Inclusive:
+		return r.From.Start(), true
+	case Exclusive:
+		return r.From.End(), true
+	}
+	return time.Time{}, false
+}
+
+// End returns when r ends, exclusive, or false if it has no end.
+func (r OpenRange) End() (time.Time, bool) {
+	switch r.EndBound {
+	case Inclusive:
+		return r.To.End(), true
+	case Exclusive:
+		return r.To.Start(), true
+	}
+	return time.Time{}, false
+}
+
+// Contains reports whether t is within r.
+func (r OpenRange) Contains(t time.Time) bool {
+	if s, ok := r.Start(); ok && t.Before(s) {
+		return false
+	}
+	if e, ok := r.End(); ok && !t.Before(e) {
+		return false
+	}
+	return true
+}
+
+// Range returns r as a Range with the finer of the granularities of its
+// bounds, or false if it is unbounded at either end.
+func (r OpenRange) Range() (Range, bool) {
+	s, ok := r.Start()
+	e, ok2 := r.End()
+	if !ok || !ok2 {
+		return Range{}, false
+	}
+	return Range{s, e.Sub(s), finer(r.From.Granularity, r.To.Granularity)}, true
+}
+
+// ParseOpenRange is like Parse but also accepts ranges with no start or no
+// end, as in "since march", "as of monday", "after next week", "before 2020",
+// "until friday", "by tomorrow" or "up to 2022". A start and an end can be
+// given together, as in "after 2019 and before 2022". The ranges after
+// "since", "as of", "until", "by" and "up to" are included, and those after
+// "after" and "before" are not. Since "since" and "as of" look back and
+// "until", "by" and "up to" look ahead, the ranges after them are read as in
+// the past or the future whatever the default direction. Ranges without any
+// of these words are returned by Range.Open.
+func ParseOpenRange(s string, now time.Time, options ...func(o *opts)) (r OpenRange, parsed string, err error) {
+	o := newOpts(options...)
+	sofw := findNextSignal(s, 0)
+	isStart, b, dir, eob, ok := parseBoundWords(s, sofw, o.dir)
+	if !ok {
+		br, parsed, _, err := parseRange(s[sofw:], now, o)
+		if err != nil {
+			return OpenRange{}, "", err
+		}
+		return br.Open(), parsed, nil
+	}
+	br, end, err := parseBound(s, eob, now, o, dir)
+	if err != nil {
+		return OpenRange{}, "", err
+	}
+	if !isStart {
+		return OpenRange{To: br, EndBound: b}, s[sofw:end], nil
+	}
+	r = OpenRange{From: br, StartBound: b}
+
+	// End, as in "since march until june" or "after 2019 and before 2022".
+	_, eoand, and := findSignalNoise(s, end)
+	if and != "and" {
+		eoand = end
+	}
+	if isStart, b, dir, eob, ok := parseBoundWords(s, eoand, o.dir); ok && !isStart {
+		if br, eobr, err := parseBound(s, eob, now, o, dir); err == nil {
+			// Ends like "june" that depend on the direction are taken to
+			// be the first such after the start.
+			other, _, err := parseBound(s, eob, now, o, opposite(dir))
+			if err == nil && !other.Equal(br) {
+				if after, _, err := parseBound(s, eob, r.From.Start(), o, Future); err == nil {
+					br = after
+				}
+			}
+			r.To, r.EndBound, end = br, b, eobr
+		}
+	}
+	return r, s[sofw:end], nil
+}
+
+// parseBoundWords parses the words that bound a range at one end, such as
+// "since" or "up to", at the first word at or after index start of s. It
+// returns whether they bound the start rather than the end, how, the
+// direction in which to read the range after them, given the default
+// direction dir, the index just past them and whether any were found.
+func parseBoundWords(s string, start int, dir Direction) (isStart bool, b Bound, boundDir Direction, end int, ok bool) {
+	_, eow, w := findSignalNoise(s, start)
+	_, eow2, w2 := findSignalNoise(s, eow)
+	switch {
+	case w == "since":
+		return true, Inclusive, Past, eow, true
+	case w == "as" && w2 == "of":
+		return true, Inclusive, Past, eow2, true
+	case w == "after":
+		return true, Exclusive, dir, eow, true
+	case w == "before":
+		return false, Exclusive, dir, eow, true
+	case w == "until" || w == "till" || w == "til" || w == "by":
+		return false, Inclusive, Future, eow, true
+	case w == "up" && w2 == "to":
+		return false, Inclusive, Future, eow2, true
+	}
+	return false, 0, 0, 0, false
+}
+
+// parseBound parses the range bounding an OpenRange at the first word at or
+// after index start of s, reading it in the direction dir. It returns the
+// range and the index just past it.
+func parseBound(s string, start int, now time.Time, o opts, dir Direction) (Range, int, error) {
+	o.dir = dir
+	sor := findNextSignal(s, start)
+	r, parsed, _, err := parseImplicitRange(s[sor:], now, o)
+	if err == ErrAmbiguousZone || err == ErrAmbiguousDate || err == ErrNoSuchDay {
+		return Range{}, 0, err
+	}
+	if err != nil {
+		return Range{}, 0, ErrNoBoundFound
+	}
+	return r, sor + len(parsed), nil
+}
@@ -0,0 +1,118 @@
+package anytime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseOpenRange(t *testing.T) {
+	date := func(y int, m time.Month, d int) time.Time {
+		return testDay(y, m, d).Start()
+	}
+	tests := []struct {
+		input     string
+		options   []func(o *opts)
+		wantStart time.Time
+		hasStart  bool
+		wantEnd   time.Time
+		hasEnd    bool
+	}{
+		{"since march", nil, date(2022, 3, 1), true, time.Time{}, false},
+		{"since march", []func(o *opts){DefaultToFuture}, date(2022, 3, 1), true, time.Time{}, false},
+		{"as of monday", nil, date(2022, 9, 26), true, time.Time{}, false},
+		{"after next week", nil, date(2022, 10, 9), true, time.Time{}, false},
+		{"before 2020ad", nil, time.Time{}, false, date(2020, 1, 1), true},
+		{"until friday", nil, time.Time{}, false, date(2022, 10, 1), true},
+		{"until friday", []func(o *opts){DefaultToPast}, time.Time{}, false, date(2022, 10, 1), true},
+		{"by the end of the month", nil, time.Time{}, false, date(2022, 10, 1), true},
+		{"up to march 3 2022", nil, time.Time{}, false, date(2022, 3, 4), true},
+		{"till tomorrow at 5pm", nil, time.Time{}, false, time.Date(2022, 9, 30, 18, 0, 0, 0, time.UTC), true},
+		{"after 2019ad and before 2022ad", nil, date(2020, 1, 1), true, date(2022, 1, 1), true},
+		{"since march until june", nil, date(2022, 3, 1), true, date(2022, 7, 1), true},
+		{"since monday until friday", nil, date(2022, 9, 26), true, date(2022, 10, 1), true},
+		{"from march 3 2022 to march 5 2022", nil, date(2022, 3, 3), true, date(2022, 3, 5), true},
+		{"tomorrow", nil, date(2022, 9, 30), true, date(2022, 10, 1), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, parsed, err := ParseOpenRange(tt.input, testNow, tt.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s, ok := got.Start(); ok != tt.hasStart || !s.Equal(tt.wantStart) {
+				t.Errorf("start is %v, %v, want %v, %v", s, ok, tt.wantStart, tt.hasStart)
+			}
+			if e, ok := got.End(); ok != tt.hasEnd || !e.Equal(tt.wantEnd) {
+				t.Errorf("end is %v, %v, want %v, %v", e, ok, tt.wantEnd, tt.hasEnd)
+			}
+			if parsed != tt.input {
+				t.Errorf("parsed %q, want %q", parsed, tt.input)
+			}
+		})
+	}
+}
+
+func TestParseOpenRange_leadingSpace(t *testing.T) {
+	for _, tt := range []struct {
+		input, want string
+	}{
+		{"  tomorrow", "tomorrow"},
+		{" \tfrom march 3 2022 to march 5 2022", "from march 3 2022 to march 5 2022"},
+		{"  since march", "since march"},
+	} {
+		t.Run(tt.input, func(t *testing.T) {
+			_, parsed, err := ParseOpenRange(tt.input, testNow)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed != tt.want {
+				t.Errorf("parsed %q, want %q", parsed, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseOpenRange_fail(t *testing.T) {
+	for _, s := range []string{"since", "before the flood", "since 03/04/2022", "  ", "  after"} {
+		t.Run(s, func(t *testing.T) {
+			got, _, err := ParseOpenRange(s, testNow)
+			if err == nil {
+				t.Errorf("got %+v, want an error", got)
+			}
+		})
+	}
+}
+
+func TestOpenRange(t *testing.T) {
+	march := truncateMonth(time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC))
+	june := truncateMonth(time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC))
+	tests := []struct {
+		name      string
+		r         OpenRange
+		t         time.Time
+		contains  bool
+		wantRange Range
+		bounded   bool
+	}{
+		{"all time", OpenRange{}, time.Date(1, 1, 1, 0, 0, 0, 0, time.UTC), true, Range{}, false},
+		{"since march, in march", OpenRange{From: march, StartBound: Inclusive}, march.Start(), true, Range{}, false},
+		{"after march, in march", OpenRange{From: march, StartBound: Exclusive}, march.Start(), false, Range{}, false},
+		{"after march, in april", OpenRange{From: march, StartBound: Exclusive}, march.End(), true, Range{}, false},
+		{"before june, in june", OpenRange{To: june, EndBound: Exclusive}, june.Start(), false, Range{}, false},
+		{"until june, in june", OpenRange{To: june, EndBound: Inclusive}, june.Start(), true, Range{}, false},
+		{"until june, in july", OpenRange{To: june, EndBound: Inclusive}, june.End(), false, Range{}, false},
+		{"march to june", OpenRange{march, Inclusive, june, Inclusive}, june.Start(), true, RangeFromTimes(march.Start(), june.End()), true},
+		{"open march", march.Open(), march.Start(), true, march, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Contains(tt.t); got != tt.contains {
+				t.Errorf("Contains(%v) = %v, want %v", tt.t, got, tt.contains)
+			}
+			got, ok := tt.r.Range()
+			if ok != tt.bounded || !got.Equal(tt.wantRange) {
+				t.Errorf("Range() = %v, %v, want %v, %v", got, ok, tt.wantRange, tt.bounded)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package anytime
+
+import "time"
+
+// opts are the settings that can be changed by passing options such as
+// DefaultToPast or WeekStartsOn to Parse and the other functions that take
+// them.
+type opts struct {
+	// dir says whether to choose the Past or Future instance of
+	// expressions like "December" that could be in either.
+	dir Direction
+
+	// weekStart is the day on which weeks start.
+	weekStart time.Weekday
+
+	// zonePolicy resolves ambiguous time zone abbreviations.
+	zonePolicy ZonePolicy
+
+	// dateOrder is the order in which numeric dates are written.
+	dateOrder DateOrder
+
+	// fiscalYearStart is the month in which fiscal years start.
+	fiscalYearStart time.Month
+
+	// portions are the parts of a period meant by "early", "mid" and
+	// "late".
+	portions PeriodPortions
+
+	// endOfDayHour is the hour meant by "EOD" and "COB".
+	endOfDayHour int
+
+	// dayParts are the parts of a day meant by words like "morning".
+	dayParts map[string]DayPart
+
+	// bareNumber is how to read a number on its own, as in "12". Such
+	// numbers are only read when ParseAll asks for one of their readings.
+	bareNumber bareNumberReading
+}
+
+// newOpts returns the default settings changed by the given options.
+func newOpts(options ...func(o *opts)) opts {
+	o := opts{
+		dir:             Future,
+		weekStart:       time.Sunday,
+		zonePolicy:      FirstZone,
+		dateOrder:       NoDateOrder,
+		fiscalYearStart: time.January,
+		portions:        thirds,
+		endOfDayHour:    defaultEndOfDayHour,
+		dayParts:        defaultDayParts(),
+	}
+	for _, optFunc := range options {
+		optFunc(&o)
+	}
+	return o
+}
+
+// DefaultToFuture sets the option to default to the future in case of
+// ambiguous dates. This is the default.
+func DefaultToFuture(o *opts) {
+	o.dir = Future
+}
+
+// DefaultToPast sets the option to default to the past in case of
+// ambiguous dates.
+func DefaultToPast(o *opts) {
+	o.dir = Past
+}
+
+// WithDirection sets the option to default to the given direction in case
+// of ambiguous dates.
+func WithDirection(dir Direction) func(o *opts) {
+	return func(o *opts) {
+		o.dir = dir
+	}
+}
+
+// WeekStartsOn sets the option to start weeks on the given day rather than
+// on Sunday. Use time.Monday for ISO 8601 weeks.
+func WeekStartsOn(d time.Weekday) func(o *opts) {
+	return func(o *opts) {
+		o.weekStart = d
+	}
+}
+
+// WithZonePolicy sets the option to resolve ambiguous time zone
+// abbreviations such as "CST" using the policy p rather than FirstZone.
+func WithZonePolicy(p ZonePolicy) func(o *opts) {
+	return func(o *opts) {
+		o.zonePolicy = p
+	}
+}
+
+// WithDateOrder sets the option to read numeric dates like "03/04/2022" in
+// the given order rather than as with NoDateOrder.
+func WithDateOrder(order DateOrder) func(o *opts) {
+	return func(o *opts) {
+		o.dateOrder = order
+	}
+}
+
+// FiscalYearStartsIn sets the option to start fiscal years, as in "FY2024",
+// in the month m rather than in January.
+func FiscalYearStartsIn(m time.Month) func(o *opts) {
+	return func(o *opts) {
+		o.fiscalYearStart = m
+	}
+}
+
+// WithPeriodPortions sets the option to take "early", "mid" and "late", as
+// in "early march", to mean the portions p of a period rather than its
+// thirds.
+func WithPeriodPortions(p PeriodPortions) func(o *opts) {
+	return func(o *opts) {
+		o.portions = p
+	}
+}
+
+// WithEndOfDayHour sets the option to take "EOD" and "COB" to mean the hour h
+// rather than 5pm.
+func WithEndOfDayHour(h int) func(o *opts) {
+	return func(o *opts) {
+		o.endOfDayHour = h
+	}
+}
+
+// WithDayParts sets the option to take the names of parts of a day, such as
+// "morning" in "tomorrow morning", to mean the hours given by parts rather
+// than the usual ones, such as 6am to noon for "morning". The names must be
+// in lower case. Changing parts afterwards does not change the option.
+func WithDayParts(parts map[string]DayPart) func(o *opts) {
+	copied := make(map[string]DayPart, len(parts))
+	for name, p := range parts {
+		copied[name] = p
+	}
+	return func(o *opts) {
+		o.dayParts = copied
+	}
+}
+
+// unit returns the unit of time for the granularity g, with weeks starting
+// on o.weekStart.
+func (o opts) unit(g Granularity) unit {
+	if g == Week {
+		return weekUnitFrom(o.weekStart)
+	}
+	return granularityToUnit[g]
+}
+
+// unitNamed returns the unit of time with the given name, such as "days",
+// with weeks starting on o.weekStart.
+func (o opts) unitNamed(name string) (unit, bool) {
+	u, ok := unitNameToUnit[name]
+	return o.unit(u.granularity), ok
+}
+
+// truncateWeek returns the week containing t, starting on o.weekStart.
+func (o opts) truncateWeek(t time.Time) Range {
+	return truncateWeekFrom(t, o.weekStart)
+}
@@ -0,0 +1,89 @@
+package anytime
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParse_options(t *testing.T) {
+	// Thursday
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	tests := []struct {
+		input   string
+		options []func(o *opts)
+		want    Range
+	}{
+		{"december", nil, truncateMonth(time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC))},
+		{"december", []func(o *opts){DefaultToFuture}, truncateMonth(time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC))},
+		{"december", []func(o *opts){DefaultToPast}, truncateMonth(time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC))},
+		{"december", []func(o *opts){WithDirection(Past)}, truncateMonth(time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC))},
+		{"this week", []func(o *opts){WeekStartsOn(time.Monday)}, Range{time.Date(2022, 9, 26, 0, 0, 0, 0, time.UTC), 7 * 24 * time.Hour, Week}},
+		{"in 2 weeks", []func(o *opts){WeekStartsOn(time.Monday)}, Range{time.Date(2022, 10, 10, 0, 0, 0, 0, time.UTC), 7 * 24 * time.Hour, Week}},
+		{"this sunday", []func(o *opts){WeekStartsOn(time.Monday)}, truncateDay(time.Date(2022, 10, 2, 0, 0, 0, 0, time.UTC))},
+		{"5pm CST", []func(o *opts){WithZonePolicy(PreferZones("China Standard Time"))}, Range{time.Date(2022, 9, 29, 17, 0, 0, 0, fixedZone(8)), time.Hour, Hour}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, parsed, err := Parse(tt.input, now, tt.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if parsed != tt.input {
+				t.Errorf("parsed %q, want %q", parsed, tt.input)
+			}
+		})
+	}
+}
+
+func TestParse_wrappers(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	s := "call me in december or on friday"
+	for _, dir := range []Direction{Future, Past} {
+		option := DefaultToFuture
+		if dir == Past {
+			option = DefaultToPast
+		}
+		got, _, _ := ParseRange("december", now, dir)
+		want, _, _ := Parse("december", now, option)
+		if !got.Equal(want) {
+			t.Errorf("ParseRange(%v) = %v, want %v", dir, got, want)
+		}
+		gotSet, _, _ := ParseRangeSet("fridays in december", now, dir)
+		wantSet, _, _ := ParseSet("fridays in december", now, option)
+		if !reflect.DeepEqual(gotSet.Ranges(), wantSet.Ranges()) {
+			t.Errorf("ParseRangeSet(%v) = %v, want %v", dir, gotSet, wantSet)
+		}
+		f := func(src string, r Range) string { return r.Start().String() }
+		if got, want := ReplaceAllRangesByFunc(s, now, dir, f), ReplaceAllByFunc(s, now, f, option); got != want {
+			t.Errorf("ReplaceAllRangesByFunc(%v) = %q, want %q", dir, got, want)
+		}
+	}
+}
+
+func TestDescribe_weekStartsOn(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	monday := WeekStartsOn(time.Monday)
+	r := Range{time.Date(2022, 9, 19, 0, 0, 0, 0, time.UTC), 7 * 24 * time.Hour, Week}
+	s := Describe(r, now, monday)
+	if want := "last week"; s != want {
+		t.Errorf("Describe() = %q, want %q", s, want)
+	}
+	if got, _, err := Parse(s, now, monday); err != nil || !got.Equal(r) {
+		t.Errorf("Parse(%q) = %v, %v, want %v", s, got, err, r)
+	}
+}
+
+func TestParseRecurrence_weekStartsOn(t *testing.T) {
+	sunday := time.Date(2022, 10, 2, 0, 0, 0, 0, time.UTC)
+	rec, _, err := ParseRecurrence("every other sunday", sunday, WeekStartsOn(time.Monday))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := rec.RRULE(), "FREQ=WEEKLY;INTERVAL=2;WKST=MO;BYDAY=SU"; got != want {
+		t.Errorf("RRULE() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,107 @@
+package anytime
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNoSuchDay is returned for expressions like "fifth monday of february"
+// that ask for a day that the period does not have.
+var ErrNoSuchDay = errors.New("no such day in the period")
+
+// errNoOrdinalDayFound is returned by parseOrdinalDay when there is no
+// ordinal day at all.
+var errNoOrdinalDayFound = errors.New("no ordinal day found")
+
+// ordinalWords maps the lower-cased words for ordinals to their values, with
+// negative values counting from the end.
+var ordinalWords = map[string]int{
+	"first":          1,
+	"1st":            1,
+	"second":         2,
+	"2nd":            2,
+	"third":          3,
+	"3rd":            3,
+	"fourth":         4,
+	"4th":            4,
+	"fifth":          5,
+	"5th":            5,
+	"last":           -1,
+	"penultimate":    -2,
+	"second-to-last": -2,
+}
+
+// isBusinessDay reports whether t is on a weekday from Monday to Friday.
+func isBusinessDay(t time.Time) bool {
+	return t.Weekday() != time.Saturday && t.Weekday() != time.Sunday
+}
+
+// parseOrdinalDay parses an ordinal day of a period, as in "the second
+// tuesday in november", "last business day of the quarter" or "the 3rd friday
+// of next month", at the first word at or after index start of s. The period
+// can be any range coarser than a day. It returns the day, the index just past
+// the expression and the kind of expression that the period is. It returns
+// errNoOrdinalDayFound if there is no such expression, or ErrNoSuchDay, along
+// with the index just past the expression, if the period has no such day, as
+// with "fifth monday of february 2022".
+func parseOrdinalDay(s string, start int, now time.Time, o opts) (Range, int, Kind, error) {
+	_, eow, w := findSignalNoise(s, start)
+	if eq(w, "the") {
+		_, eow, w = findSignalNoise(s, eow)
+	}
+
+	// Ordinal, as in "third" or "second to last"
+	n, ok := ordinalWords[w]
+	if !ok {
+		return Range{}, 0, 0, errNoOrdinalDayFound
+	}
+	_, eow2, w2 := findSignalNoise(s, eow)
+	_, eow3, w3 := findSignalNoise(s, eow2)
+	if n == 2 && eq(w2, "to") && eq(w3, "last") {
+		n, eow = -2, eow3
+	}
+
+	// Day, as in "tuesday", "business day" or "day"
+	_, eow, w = findSignalNoise(s, eow)
+	var match func(t time.Time) bool
+	if wd, ok := weekdayNameToWeekday[w]; ok {
+		match = func(t time.Time) bool { return t.Weekday() == wd }
+	} else if eq(w, "weekday") {
+		match = isBusinessDay
+	} else if eq(w, "business") {
+		_, eod, d := findSignalNoise(s, eow)
+		if !eq(d, "day") {
+			return Range{}, 0, 0, errNoOrdinalDayFound
+		}
+		match, eow = isBusinessDay, eod
+	} else if eq(w, "day") {
+		match = func(time.Time) bool { return true }
+	} else {
+		return Range{}, 0, 0, errNoOrdinalDayFound
+	}
+
+	_, eoof, of := findSignalNoise(s, eow)
+	if !eq(of, "of") && !eq(of, "in") {
+		return Range{}, 0, 0, errNoOrdinalDayFound
+	}
+
+	// Period, as in "the month", "november" or "q3 2022"
+	p, eop, kind, ok := parseCoarseRange(s, eoof, now, o)
+	if !ok {
+		return Range{}, 0, 0, errNoOrdinalDayFound
+	}
+
+	var days []time.Time
+	for d := p.start; d.Before(p.End()); d = d.AddDate(0, 0, 1) {
+		if match(d) {
+			days = append(days, d)
+		}
+	}
+	if n < 0 {
+		n += len(days) + 1
+	}
+	if n < 1 || n > len(days) {
+		return Range{}, eop, 0, ErrNoSuchDay
+	}
+	return truncateDay(days[n-1]), eop, kind, nil
+}
@@ -0,0 +1,58 @@
+package anytime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_ordinalDays(t *testing.T) {
+	testParse(t, []parseTest{
+		{"the second tuesday in november", nil, testDay(2022, 11, 8)},
+		{"first monday of march 2023", nil, testDay(2023, 3, 6)},
+		{"1st monday of march", []func(o *opts){DefaultToPast}, testDay(2022, 3, 7)},
+		{"the 3rd friday of next month", nil, testDay(2022, 10, 21)},
+		{"last friday of the month", nil, testDay(2022, 9, 30)},
+		{"last business day of the quarter", nil, testDay(2022, 9, 30)},
+		{"first business day of next quarter", nil, testDay(2022, 10, 3)},
+		{"last weekday of december 2022", nil, testDay(2022, 12, 30)},
+		{"second to last friday of the year", nil, testDay(2022, 12, 23)},
+		{"second-to-last day of february 2024", nil, testDay(2024, 2, 28)},
+		{"fifth monday of october 2022", nil, testDay(2022, 10, 31)},
+		{"first sunday of the week", []func(o *opts){WeekStartsOn(time.Monday)}, testDay(2022, 10, 2)},
+		{"last day of q1 2023", nil, testDay(2023, 3, 31)},
+		{"last friday of 2021", nil, testDay(2021, 12, 31)},
+		{"first monday of fy23", []func(o *opts){FiscalYearStartsIn(time.October)}, testDay(2022, 10, 3)},
+	})
+}
+
+func TestParse_ordinalDaysAtTime(t *testing.T) {
+	got, _, err := Parse("first monday of march 2023 at 9am", testNow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Range{time.Date(2023, 3, 6, 9, 0, 0, 0, time.UTC), time.Hour, Hour}); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestParse_noSuchDay(t *testing.T) {
+	for _, s := range []string{
+		"fifth monday of february 2022",
+		"the fifth friday in june 2022",
+		"5pm on the fifth monday of february 2022",
+	} {
+		t.Run(s, func(t *testing.T) {
+			got, _, err := Parse(s, testNow)
+			if err != ErrNoSuchDay {
+				t.Errorf("got %v, %v, want ErrNoSuchDay", got, err)
+			}
+		})
+	}
+}
+
+func TestFindAll_noSuchDay(t *testing.T) {
+	matches := FindAll("on the fifth monday of february 2022 or tomorrow", testNow)
+	if len(matches) != 1 || matches[0].Src != "tomorrow" {
+		t.Errorf("got %+v, want just tomorrow", matches)
+	}
+}
@@ -3,6 +3,7 @@ package anytime
 import (
 	"errors"
 	"fmt"
+	"math"
 	"regexp"
 	"strconv"
 	"strings"
@@ -25,84 +26,308 @@ func (e *ErrNoConnectorFound) Error() string {
 var ErrNoRangeFound = errors.New("no range found")
 var ErrNoImplicitRangeFound = errors.New("no implicit range found")
 
-// ParseRange parses either an explicit range or an implicit range starting
-// at the beginning of s. A lower-cased version of s is given as ls.
+// Parse parses either an explicit range or an implicit range starting at
+// the beginning of s, relative to now and with the given options. It returns
+// the range and the prefix of s that was parsed.
+func Parse(s string, now time.Time, options ...func(o *opts)) (r Range, parsed string, err error) {
+	r, parsed, _, err = parseRange(s, now, newOpts(options...))
+	return r, parsed, err
+}
+
+// ParseRange is like Parse, choosing the instance of ambiguous dates given
+// by dir.
 func ParseRange(s string, now time.Time, dir Direction) (r Range, parsed string, err error) {
+	return Parse(s, now, WithDirection(dir))
+}
+
+// ParseSet parses an expression at the beginning of s that may denote
+// several disjoint ranges, such as "weekdays in march",
+// "mondays and wednesdays next month" or "march 3 and march 5". Anything
+// accepted by Parse is also accepted, giving a set of one range.
+func ParseSet(s string, now time.Time, options ...func(o *opts)) (rs RangeSet, parsed string, err error) {
+	return parseSet(s, now, newOpts(options...))
+}
+
+// ParseRangeSet is like ParseSet, choosing the instance of ambiguous dates
+// given by dir.
+func ParseRangeSet(s string, now time.Time, dir Direction) (rs RangeSet, parsed string, err error) {
+	return ParseSet(s, now, WithDirection(dir))
+}
+
+// parseSet is ParseSet with the options already applied.
+func parseSet(s string, now time.Time, o opts) (rs RangeSet, parsed string, err error) {
+	sofw := findNextSignal(s, 0)
+
+	// Days of the week within a period, as in "weekends in march".
+	if days, eod, ok := parseWeekdaySet(s, sofw); ok {
+		_, eoin, in := findSignalNoise(s, eod)
+		if !eq(in, "in") && !eq(in, "of") && !eq(in, "during") {
+			eoin = eod
+		}
+		sop := findNextSignal(s, eoin)
+		p, parsedP, _, err := parseImplicitDateRange(s[sop:], now, o)
+		if err == nil {
+			return weekdaysWithin(p, days), s[sofw : sop+len(parsedP)], nil
+		}
+	}
+
+	// Ranges joined by "and", as in "march 3 and march 5".
+	r, parsed, _, err := parseRange(s[sofw:], now, o)
+	if err != nil {
+		return RangeSet{}, "", err
+	}
+	ranges := []Range{r}
+	eor := sofw + len(parsed)
+	for {
+		_, eoand, and := findSignalNoise(s, eor)
+		if !eq(and, "and") {
+			break
+		}
+		sonext := findNextSignal(s, eoand)
+		next, parsedNext, _, err := parseRange(s[sonext:], now, o)
+		if err != nil {
+			break
+		}
+		ranges = append(ranges, next)
+		eor = sonext + len(parsedNext)
+	}
+	return NewRangeSet(ranges...), s[sofw:eor], nil
+}
+
+// parseRange is like Parse but also returns the kind of expression that
+// was parsed.
+func parseRange(s string, now time.Time, o opts) (r Range, parsed string, kind Kind, err error) {
+	r, parsed, kind, err = parseUnsharedRange(s, now, o)
+
+	// Ranges whose ends share things, as in "march 3-5, 2022", are taken
+	// over the reading of their ends on their own if they are longer, or as
+	// long and that reading is also an explicit range, as with "9am to 5pm
+	// on tuesday". Otherwise, expressions like "second to last friday"
+	// would be split at the "to".
+	if shared, sharedParsed, ok := parseSharedRange(s, now, o); ok && (err != nil || len(sharedParsed) > len(parsed) || (len(sharedParsed) == len(parsed) && kind == ExplicitRange)) {
+		return shared, sharedParsed, ExplicitRange, nil
+	}
+	return r, parsed, kind, err
+}
+
+// parseUnsharedRange is like parseRange but only accepts explicit ranges
+// whose ends are complete on their own.
+func parseUnsharedRange(s string, now time.Time, o opts) (r Range, parsed string, kind Kind, err error) {
 	eow1 := findNextNoise(s, 0)
 	w1 := s[:eow1]
 
 	// "from A to B" for implicit ranges A and B:
 	if eq(w1, "from") {
 		sow2 := findNextSignal(s, eow1)
-		startRange, parsedStart, err := parseImplicitRange(s[sow2:], now, dir)
+		startRange, parsedStart, _, err := parseImplicitRange(s[sow2:], now, o)
 		if err != nil {
-			return Range{}, "", ErrNoRangeStartFound
+			return Range{}, "", 0, ErrNoRangeStartFound
 		}
 		eoStart := sow2 + len(parsedStart)
 		_, eoto, to := findSignalNoise(s, eoStart)
 		if !isConnector(to) {
-			return Range{}, "", &ErrNoConnectorFound{parsedStart, to}
+			return Range{}, "", 0, &ErrNoConnectorFound{parsedStart, to}
 		}
 		soEnd := findNextSignal(s, eoto)
-		endRange, parsedEnd, err := parseImplicitRange(s[soEnd:], now, dir)
+		endRange, parsedEnd, err := parseRangeEnd(s[soEnd:], startRange, now, o)
 		if err != nil {
-			return Range{}, "", ErrNoRangeEndFound
+			return Range{}, "", 0, ErrNoRangeEndFound
+		}
+		r := Range{
+			startRange.Start(),
+			endRange.Start().Sub(startRange.Start()),
+			finer(startRange.Granularity, endRange.Granularity),
 		}
-		r := Range{startRange.Start(), endRange.Start().Sub(startRange.Start())}
 		eoEnd := soEnd + len(parsedEnd)
-		return r, s[:eoEnd], nil
+		return r, s[:eoEnd], ExplicitRange, nil
 	}
 
 	// Either "A" or "A to B":
-	r, parsed, err = parseImplicitRange(s, now, dir)
+	r, parsed, kind, err = parseImplicitRange(s, now, o)
+	if err == ErrAmbiguousZone || err == ErrAmbiguousDate || err == ErrNoSuchDay {
+		return Range{}, "", 0, err
+	}
 	if err != nil {
-		return Range{}, "", ErrNoImplicitRangeFound
+		return Range{}, "", 0, ErrNoImplicitRangeFound
 	}
 	eor := len(parsed)
 	_, eoto, to := findSignalNoise(s, eor)
 	if !isConnector(to) {
-		return r, parsed, nil
+		return r, parsed, kind, nil
 	}
 	soEnd := findNextSignal(s, eoto)
-	endRange, parsedEnd, err := parseImplicitRange(s[soEnd:], now, dir)
+	endRange, parsedEnd, err := parseRangeEnd(s[soEnd:], r, now, o)
 	if err != nil {
 		// If we can't parse the end of the range, we'll just return the
 		// start of the range.
-		return r, parsed, nil
+		return r, parsed, kind, nil
+	}
+	r = Range{
+		r.Start(),
+		endRange.Start().Sub(r.Start()),
+		finer(r.Granularity, endRange.Granularity),
 	}
-	r = Range{r.Start(), endRange.Start().Sub(r.Start())}
 	eoEnd := soEnd + len(parsedEnd)
-	return r, s[:eoEnd], nil
+	return r, s[:eoEnd], ExplicitRange, nil
+}
+
+// parseRangeEnd parses the implicit range at the beginning of s that ends an
+// explicit range beginning with start. Ends like "friday" that could be in the
+// past or the future are taken to be after start when the reading given by
+// o.dir would put them before it, so that "from monday to friday" runs
+// forwards.
+func parseRangeEnd(s string, start Range, now time.Time, o opts) (Range, string, error) {
+	end, parsed, _, err := parseImplicitRange(s, now, o)
+	if err != nil || !end.Start().Before(start.Start()) {
+		return end, parsed, err
+	}
+	reversed := o
+	reversed.dir = opposite(o.dir)
+	other, _, _, err := parseImplicitRange(s, now, reversed)
+	if err != nil || other.Equal(end) {
+		// The end does not depend on the direction.
+		return end, parsed, nil
+	}
+	forward := o
+	forward.dir = Future
+	after, parsedAfter, _, err := parseImplicitRange(s, start.Start(), forward)
+	if err != nil || parsedAfter != parsed {
+		return end, parsed, nil
+	}
+	return after, parsed, nil
+}
+
+// opposite returns the direction opposite to dir.
+func opposite(dir Direction) Direction {
+	if dir == Future {
+		return Past
+	}
+	return Future
 }
 
 func isConnector(s string) bool {
-	return s == "to" || s == "until" || s == "til" || s == "through" || s == "-"
+	return s == "to" || s == "until" || s == "til" || s == "through" || s == "-" || s == "–" || s == "—"
 }
 
 func eq(a, b string) bool {
 	return strings.EqualFold(a, b)
 }
 
-// parseImplicitRange parses an implicit date range from a string s.
-// An implicit date range is something like "2022ad" as opposed to
-// an explicit range like "from 2020ad to 2022ad".
+// parseImplicitRange parses an implicit range from a string s.
+// An implicit range is something like "2022ad" or "tomorrow at 5pm" as
+// opposed to an explicit range like "from 2020ad to 2022ad".
+//
+// Times of day such as "5pm", "10:30am", "17:45:10" or "noon" are accepted on
+// their own, in which case they refer to the day of now, or attached to a
+// date expression naming a single day, as in "tomorrow at 5pm" or
+// "5pm on march 3". The resulting range lasts for an hour, a minute or a
+// second depending on how precisely the time was given.
 //
-// The lower-cased version of s is given as ls. The prefix of s that was parsed
-// is also returned. If no range is found at the very beginning of s,
+// Parts of a day such as "morning" can likewise follow a date expression
+// naming a single day, as in "friday evening", and "tonight", "last night",
+// "midnight", "overnight" and "this afternoon" are accepted on their own.
+// The resulting range covers the hours given by o.dayParts.
+//
+// The prefix of s that was parsed is also returned, along with the kind of
+// expression it is. If no range is found at the very beginning of s,
 // ErrNoRangeFound is returned.
-func parseImplicitRange(s string, now time.Time, dir Direction) (r Range, parsed string, err error) {
+func parseImplicitRange(s string, now time.Time, o opts) (r Range, parsed string, kind Kind, err error) {
+	sofw := findNextSignal(s, 0)
+	if sofw == len(s) {
+		return Range{}, "", 0, ErrNoRangeFound
+	}
+
+	// Part of a day on its own, as in "tonight" or "this morning".
+	if r, eop, ok := parseDayPartAlone(s, sofw, now, o); ok {
+		return r, s[sofw:eop], Relative, nil
+	}
+
+	// Time of day first, as in "5pm" or "5pm on march 3".
+	c, eoc, err := parseClock(s, sofw, o.zonePolicy)
+	if err == ErrAmbiguousZone {
+		return Range{}, "", 0, err
+	}
+	if err == nil {
+		_, eoon, on := findSignalNoise(s, eoc)
+		if !eq(on, "on") {
+			eoon = eoc
+		}
+		sod := findNextSignal(s, eoon)
+		d, parsedD, kind, err := parseImplicitDateRange(s[sod:], now, o)
+		if err == ErrAmbiguousDate || err == ErrNoSuchDay {
+			return Range{}, "", 0, err
+		}
+		if err == nil && isDay(d) {
+			return c.on(d), s[sofw : sod+len(parsedD)], kind, nil
+		}
+		return c.on(truncateDay(now)), s[sofw:eoc], Relative, nil
+	}
+
+	r, parsed, kind, err = parseImplicitDateRange(s[sofw:], now, o)
+	if err == ErrNoRangeFound {
+		// Number on its own, as in "12", if ParseAll asks for it.
+		if r, eon, ok := parseBareNumber(s, sofw, now, o); ok {
+			return r, s[sofw:eon], Relative, nil
+		}
+	}
+	if err != nil {
+		return Range{}, "", 0, err
+	}
+	eod := sofw + len(parsed)
+	if !isDay(r) {
+		return r, parsed, kind, nil
+	}
+
+	// Date followed by a part of the day, as in "tomorrow morning".
+	if p, eop, ok := parseDayPart(s, eod, o); ok {
+		return p.on(r), s[sofw:eop], kind, nil
+	}
+
+	// Date followed by a time of day, as in "tomorrow at 5pm".
+	_, eoat, at := findSignalNoise(s, eod)
+	if !eq(at, "at") {
+		eoat = eod
+	}
+	c, eoc, err = parseClock(s, findNextSignal(s, eoat), o.zonePolicy)
+	if err == ErrAmbiguousZone {
+		return Range{}, "", 0, err
+	}
+	if err == nil {
+		return c.on(r), s[sofw:eoc], kind, nil
+	}
+	return r, parsed, kind, nil
+}
+
+// parseImplicitDateRange parses an implicit range at the beginning of s that
+// does not involve a time of day, such as "last week" or "march 3".
+func parseImplicitDateRange(s string, now time.Time, o opts) (r Range, parsed string, kind Kind, err error) {
 	// sofw is the start of the first word in s[p:].
 	// eofw is the end of the first word in s[p:]
 	// fw is the first word.
 	sofw, eofw, fw := findSignalNoise(s, 0)
 	if sofw == len(s) {
-		return Range{}, "", ErrNoRangeFound
+		return Range{}, "", 0, ErrNoRangeFound
 	}
 
 	// Try for a match with "now", "today", etc.
 	r, ok := oneWordStrToRange(fw, now)
 	if ok {
-		return r, s[sofw:eofw], nil
+		return r, s[sofw:eofw], Relative, nil
+	}
+
+	// Try for a match with an ordinal day of a period, as in "the second
+	// tuesday in november" or "last business day of the quarter".
+	if r, eod, kind, err := parseOrdinalDay(s, sofw, now, o); err != errNoOrdinalDayFound {
+		if err != nil {
+			return Range{}, "", 0, err
+		}
+		return r, s[sofw:eod], kind, nil
+	}
+
+	// Try for a match with a part of a period or the end of a day, as in
+	// "end of this month", "mid-march" or "EOD friday".
+	if r, eoa, kind, ok := parseAnchor(s, sofw, now, o); ok {
+		return r, s[sofw:eoa], kind, nil
 	}
 
 	// Try for a match with "last week", "this month", "next year", etc.
@@ -112,14 +337,57 @@ func parseImplicitRange(s string, now time.Time, dir Direction) (r Range, parsed
 		// sw is the second word in s[p:].
 		_, eosw, sw := findSignalNoise(s, eofw)
 		fwsw := fw + " " + sw
-		r, ok = lastThisNextStrToRange(fwsw, now)
+		r, ok = lastThisNextStrToRange(fwsw, now, o)
 		if ok {
-			return r, s[sofw:eosw], nil
+			return r, s[sofw:eosw], Relative, nil
+		}
+
+		// "last friday", "this tue", "next monday"
+		if wd, ok := weekdayNameToWeekday[sw]; ok {
+			switch fw {
+			case "last":
+				r = prevWeekdayFrom(now, wd)
+			case "this":
+				r = thisWeekdayFrom(now, wd, o.weekStart)
+			case "next":
+				r = nextWeekdayFrom(now, wd)
+			}
+			return r, s[sofw:eosw], Relative, nil
+		}
+	}
+
+	// Try for a match with a weekday on its own, as in "friday", or of a
+	// given week, as in "friday next week".
+	if wd, ok := weekdayNameToWeekday[fw]; ok {
+		_, eow2, w2 := findSignalNoise(s, eofw)
+		_, eow3, w3 := findSignalNoise(s, eow2)
+		if week, ok := lastThisNextStrToRange(w2+" "+w3, now, o); ok && week.Granularity == Week {
+			r = truncateDay(week.start.AddDate(0, 0, (int(wd)-int(o.weekStart)+7)%7))
+			return r, s[sofw:eow3], Relative, nil
+		}
+		if o.dir == Future {
+			r = nextWeekdayFrom(now, wd)
+		} else {
+			r = prevWeekdayFrom(now, wd)
 		}
+		return r, s[sofw:eofw], Relative, nil
+	}
+
+	// Try for a match with a quarter, half or fiscal year, as in "q3 2022",
+	// "h1" or "fy23 q2".
+	if r, eoq, kind, ok := parseQuarter(s, sofw, now, o); ok {
+		return r, s[sofw:eoq], kind, nil
+	}
+
+	// Try for a match with an ISO 8601 week date or ordinal date or a week
+	// number, as in "2022-W05", "2022-256" or "week 42 2023".
+	if r, eow, kind, ok := parseWeekDate(s, sofw, now, o); ok {
+		return r, s[sofw:eow], kind, nil
 	}
 
 	// Try for a match with
-	// "N days ago", "N days from now",
+	// "N seconds ago", "N minutes from now",
+	// "N hours hence", "N days ago",
 	// "N weeks ago"
 	// "N months ago"
 	// "N years ago"
@@ -131,63 +399,38 @@ func parseImplicitRange(s string, now time.Time, dir Direction) (r Range, parsed
 		if i >= 1000 && i <= 9999 && (eq(w2, "ad") || eq(w2, "ce")) {
 			// Year
 			r := truncateYear(time.Date(i, 1, 1, 0, 0, 0, 0, now.Location()))
-			return r, s[sofw:eow2], nil
+			return r, s[sofw:eow2], Absolute, nil
 		}
-		if eq(w2, "day") || eq(w2, "days") {
+		if u, ok := o.unitNamed(w2); ok && u.fits(i) {
 			if eq(w3, "ago") {
-				r := truncateDay(now.AddDate(0, 0, -i))
-				return r, s[sofw:eow3], nil
+				r := u.truncate(u.add(now, -i))
+				return r, s[sofw:eow3], Relative, nil
 			}
 			if eq(w3, "hence") {
-				r := truncateDay(now.AddDate(0, 0, i))
-				return r, s[sofw:eow3], nil
+				r := u.truncate(u.add(now, i))
+				return r, s[sofw:eow3], Relative, nil
 			}
 			if eq(w3, "from") && (eq(w4, "now") || eq(w4, "today")) {
-				r := truncateDay(now.AddDate(0, 0, i))
-				return r, s[sofw:eow4], nil
+				r := u.truncate(u.add(now, i))
+				return r, s[sofw:eow4], Relative, nil
 			}
 		}
-		if eq(w2, "week") || eq(w2, "weeks") {
-			if w3 == "ago" {
-				r := truncateWeek(now.AddDate(0, 0, -i*7))
-				return r, s[sofw:eow3], nil
-			}
-			if eq(w3, "hence") {
-				r := truncateWeek(now.AddDate(0, 0, i*7))
-				return r, s[sofw:eow3], nil
-			}
-			if eq(w3, "from") && (eq(w4, "now") || eq(w4, "today")) {
-				r := truncateWeek(now.AddDate(0, 0, i*7))
-				return r, s[sofw:eow4], nil
-			}
-		}
-		if eq(w2, "month") || eq(w2, "months") {
-			if eq(w3, "ago") {
-				r := truncateMonth(now.AddDate(0, -i, 0))
-				return r, s[sofw:eow3], nil
-			}
-			if eq(w3, "hence") {
-				r := truncateMonth(now.AddDate(0, i, 0))
-				return r, s[sofw:eow3], nil
-			}
-			if eq(w3, "from") && (eq(w4, "now") || eq(w4, "today")) {
-				r := truncateMonth(now.AddDate(0, i, 0))
-				return r, s[sofw:eow4], nil
-			}
-		}
-		if eq(w2, "year") || eq(w2, "years") {
-			if eq(w3, "ago") {
-				r := truncateYear(now.AddDate(-i, 0, 0))
-				return r, s[sofw:eow3], nil
-			}
-			if eq(w3, "hence") {
-				r := truncateYear(now.AddDate(i, 0, 0))
-				return r, s[sofw:eow3], nil
-			}
-			if eq(w3, "from") && (eq(w4, "now") || eq(w4, "today")) {
-				r := truncateYear(now.AddDate(i, 0, 0))
-				return r, s[sofw:eow4], nil
+	}
+
+	// Try for a match with "in N units" or "within N units".
+	if eq(fw, "in") || eq(fw, "within") {
+		_, eow2, w2 := findSignalNoise(s, eofw)
+		_, eow3, w3 := findSignalNoise(s, eow2)
+		i, ok := parseInt(w2)
+		u, ok2 := o.unitNamed(w3)
+		if ok && ok2 && i >= 0 && u.fits(i) {
+			if eq(fw, "in") {
+				r := u.truncate(u.add(now, i))
+				return r, s[sofw:eow3], Relative, nil
 			}
+			r := RangeFromTimes(now, u.add(now, i))
+			r.Granularity = u.granularity
+			return r, s[sofw:eow3], Relative, nil
 		}
 	}
 
@@ -196,7 +439,7 @@ func parseImplicitRange(s string, now time.Time, dir Direction) (r Range, parsed
 		_, eosw, sw := findSignalNoise(s, eofw)
 		r, ok = colorMonthToRange(delta, sw, now)
 		if ok {
-			return r, s[sofw:eosw], nil
+			return r, s[sofw:eosw], ColorMonth, nil
 		}
 	}
 
@@ -204,8 +447,8 @@ func parseImplicitRange(s string, now time.Time, dir Direction) (r Range, parsed
 	if rfc3339Rx.MatchString(s[sofw:eofw]) {
 		t, err := time.Parse(time.RFC3339, s[sofw:eofw])
 		if err == nil {
-			r := Range{t, time.Second}
-			return r, s[sofw:eofw], nil
+			r := Range{t, time.Second, Second}
+			return r, s[sofw:eofw], Absolute, nil
 		}
 	}
 
@@ -223,7 +466,50 @@ func parseImplicitRange(s string, now time.Time, dir Direction) (r Range, parsed
 	code := ""
 	for sow < len(s) {
 		prevD := d
-		wCode, ok := parseDateWord(&d, w)
+		// "the" before a day of the month, as in "the 3rd of may", and "of"
+		// after one
+		if code == "" && w == "the" {
+			_, _, w2 := findSignalNoise(s, eow)
+			if _, ok := parseDayOfMonth(w2); ok {
+				sow, eow, w = findSignalNoise(s, eow)
+				continue
+			}
+		}
+		if code == "d" && w == "of" {
+			sow, eow, w = findSignalNoise(s, eow)
+			continue
+		}
+		// Numeric date, as in "2022-03-04", "03/04/2022", "03.04.2022" or,
+		// given the date order, "3/4"
+		nd, n, err := parseNumericDate(s[sow:], o.dateOrder)
+		if err == ErrAmbiguousDate {
+			return Range{}, "", 0, err
+		}
+		var wCode string
+		var ok bool
+		if err == nil {
+			nd.loc = d.loc
+			d = nd
+			wCode, ok = "ymd", true
+			if nd.year == 0 {
+				wCode = "md"
+			}
+			eow = sow + n
+		} else {
+			wCode, ok = parseDateWord(&d, w)
+		}
+		if !ok {
+			// Time zone, as in "march 3 pacific time"
+			loc, eoz, err := parseZone(s, sow, o.zonePolicy)
+			if err == ErrAmbiguousZone {
+				return Range{}, "", 0, err
+			}
+			if err == nil {
+				d.loc = loc
+				wCode, ok = "z", true
+				eow = eoz
+			}
+		}
 		if !ok {
 			d = prevD
 			break
@@ -250,23 +536,53 @@ func parseImplicitRange(s string, now time.Time, dir Direction) (r Range, parsed
 		// The only valid thing that can come after a day of month is a specific
 		// month. Also, a day of month by itself is not enough to be
 		// unambiguous. So bail.
-		return Range{}, "", ErrNoRangeFound
+		return Range{}, "", 0, ErrNoRangeFound
 	}
 
-	r, ok = inferRange(d, now, dir, strings.ToLower(s[sofw:eolgw]))
+	r, ok = inferRange(d, now, o.dir, strings.ToLower(s[sofw:eolgw]))
 	if !ok {
 		// Not enough information was given, so skip it.
-		return Range{}, "", ErrNoRangeFound
+		return Range{}, "", 0, ErrNoRangeFound
 	}
 
-	// Got enough information to specify an implicit date range.
-	return r, s[sofw:eolgw], nil
+	// Got enough information to specify an implicit date range. It only
+	// depends on now if the year was left out.
+	kind = Absolute
+	if d.year == 0 {
+		kind = Relative
+	}
+	return r, s[sofw:eolgw], kind, nil
+}
+
+// parseCoarseRange parses a range longer than a day, such as "the month",
+// "november" or "q3 2022", at the first word at or after index start of s.
+// The name of a unit, with or without "the", means the one containing now,
+// and a year can be given on its own, as in "2023", since it cannot be taken
+// for some other number here. It returns the range, the index just past it, its kind and whether one was
+// found.
+func parseCoarseRange(s string, start int, now time.Time, o opts) (Range, int, Kind, bool) {
+	sow, eow, w := findSignalNoise(s, start)
+	eou, uw := eow, w
+	if eq(w, "the") {
+		_, eou, uw = findSignalNoise(s, eow)
+	}
+	if u, ok := o.unitNamed(uw); ok && u.granularity > Day {
+		return u.truncate(now), eou, Relative, true
+	}
+	if y, err := strconv.Atoi(w); err == nil && len(w) == 4 && okYear(y) {
+		return truncateYear(time.Date(y, 1, 1, 0, 0, 0, 0, now.Location())), eow, Absolute, true
+	}
+	r, parsed, kind, err := parseImplicitDateRange(s[sow:], now, o)
+	if err != nil || r.Granularity <= Day {
+		return Range{}, 0, 0, false
+	}
+	return r, sow + len(parsed), kind, true
 }
 
 // parseDateWord sets a field of d based on the given word w and returns
 // true if it can. If no usable information is found, it returns false.
 // It also returns a string signifying which type of thing was found:
-// "y" for year, "m" for month, "d" for day, "ymd" for "YYYY/MM/DD" etc.
+// "y" for year, "m" for month or "d" for day.
 func parseDateWord(d *date, w string) (string, bool) {
 	// Year
 	if len(w) == 4 {
@@ -283,28 +599,6 @@ func parseDateWord(d *date, w string) (string, bool) {
 		return "d", true
 	}
 
-	// YYYY/MM/DD
-	if sm := ymdRx.FindStringSubmatch(w); sm != nil {
-		y, _ := strconv.Atoi(sm[1])
-		m, _ := strconv.Atoi(sm[2])
-		dom, _ := strconv.Atoi(sm[3])
-		d.year = y
-		d.month = time.Month(m)
-		d.dayOfMonth = dom
-		return "ymd", true
-	}
-
-	// DD/MM/YYYY
-	if sm := dmyRx.FindStringSubmatch(w); sm != nil {
-		dom, _ := strconv.Atoi(sm[1])
-		m, _ := strconv.Atoi(sm[2])
-		y, _ := strconv.Atoi(sm[3])
-		d.year = y
-		d.month = time.Month(m)
-		d.dayOfMonth = dom
-		return "dmy", true
-	}
-
 	// Month
 	m, ok := monthNameToMonth[w]
 	if ok {
@@ -346,21 +640,6 @@ func parseDateWord(d *date, w string) (string, bool) {
 		}
 	}
 
-	// UTC time zone
-	if w == "utc" {
-		d.loc = time.UTC
-		return "z", true
-	}
-
-	// Time zone like "utc+8"
-	if (len(w) == len("utc+1") || len(w) == len("utc+10")) && w[:3] == "utc" {
-		h, err := strconv.Atoi(w[3:])
-		if err == nil && h >= -12 && h <= 12 {
-			d.loc = fixedZone(h)
-			return "z", true
-		}
-	}
-
 	// 1999AD
 	if len(w) == len("1999ad") && (w[4:] == "ad" || w[4:] == "ce") {
 		y, err := strconv.Atoi(w[:4])
@@ -373,6 +652,189 @@ func parseDateWord(d *date, w string) (string, bool) {
 	return "", false
 }
 
+// errNoClockFound is returned by parseClock when there is no time of day.
+var errNoClockFound = errors.New("no time of day found")
+
+// clock is a time of day such as "5pm" or "17:45:10".
+type clock struct {
+	hour, minute, second int
+
+	// granularity is how precisely the time was given: Hour for "5pm",
+	// Minute for "5:30pm" and Second for "5:30:15pm".
+	granularity Granularity
+
+	// loc is the time zone given after the time, if any.
+	loc *time.Location
+}
+
+// on returns the range for the clock time c on the day starting range d.
+func (c clock) on(d Range) Range {
+	loc := c.loc
+	if loc == nil {
+		loc = d.start.Location()
+	}
+	y, m, dom := d.start.Date()
+	t := time.Date(y, m, dom, c.hour, c.minute, c.second, 0, loc)
+	switch c.granularity {
+	case Hour:
+		return truncateHour(t)
+	case Minute:
+		return truncateMinute(t)
+	}
+	return truncateSecond(t)
+}
+
+// parseClock parses a time of day from the word starting at index start of s,
+// possibly followed by "am" or "pm" and a time zone. It returns the clock and
+// the index just past the last word used, errNoClockFound if there is no time
+// of day there, or ErrAmbiguousZone if the time zone could not be resolved.
+func parseClock(s string, start int, policy ZonePolicy) (c clock, end int, err error) {
+	var ok bool
+	_, eow, w := findSignalNoise(s, start)
+	_, eow2, w2 := findSignalNoise(s, eow)
+	switch {
+	case w == "noon":
+		c = clock{hour: 12, granularity: Hour}
+		end = eow
+
+	case clock12Rx.MatchString(w):
+		c, ok = clockFromMatch(clock12Rx.FindStringSubmatch(w))
+		if !ok {
+			return clock{}, 0, errNoClockFound
+		}
+		end = eow
+
+	case clock24Rx.MatchString(w) || hourRx.MatchString(w):
+		// "17:45", or "5:30 pm" or "5 pm" with a space before the am/pm.
+		if eq(w2, "am") || eq(w2, "pm") {
+			c, ok = clockFromMatch(clock12Rx.FindStringSubmatch(w + w2))
+			end = eow2
+		} else if clock24Rx.MatchString(w) {
+			c, ok = clockFromMatch(clock24Rx.FindStringSubmatch(w))
+			end = eow
+		}
+		if !ok {
+			return clock{}, 0, errNoClockFound
+		}
+
+	default:
+		return clock{}, 0, errNoClockFound
+	}
+
+	loc, eoz, err := parseZone(s, end, policy)
+	switch err {
+	case nil:
+		c.loc = loc
+		end = eoz
+	case ErrAmbiguousZone:
+		return clock{}, 0, err
+	}
+	return c, end, nil
+}
+
+// clockFromMatch makes a clock from a submatch of clock12Rx or clock24Rx,
+// checking that its fields are in range.
+func clockFromMatch(sm []string) (clock, bool) {
+	if sm == nil {
+		return clock{}, false
+	}
+	c := clock{granularity: Hour}
+	c.hour, _ = strconv.Atoi(sm[1])
+	if sm[2] != "" {
+		c.minute, _ = strconv.Atoi(sm[2])
+		c.granularity = Minute
+	}
+	if sm[3] != "" {
+		c.second, _ = strconv.Atoi(sm[3])
+		c.granularity = Second
+	}
+	if c.minute > 59 || c.second > 59 {
+		return clock{}, false
+	}
+	if len(sm) > 4 {
+		// 12-hour clock
+		if c.hour < 1 || c.hour > 12 {
+			return clock{}, false
+		}
+		c.hour %= 12
+		if sm[4] == "pm" {
+			c.hour += 12
+		}
+	} else if c.hour > 23 {
+		return clock{}, false
+	}
+	return c, true
+}
+
+// parseWeekdaySet parses a list of days of the week starting at start in s,
+// such as "weekdays", "mondays" or "tuesdays, thursdays and saturdays". To
+// avoid claiming expressions like "friday" that name a single day, the list
+// must have more than one item or use a plural. It returns the days named,
+// indexed by time.Weekday, and the end of the list.
+func parseWeekdaySet(s string, start int) (days [7]bool, end int, ok bool) {
+	days, n, plural, end := parseWeekdays(s, start)
+	return days, end, n > 1 || plural
+}
+
+// parseWeekdays parses a possibly empty list of days of the week joined by
+// "and" or commas, starting at start in s. Besides the names of days and
+// their plurals, the list may contain "weekday" and "weekend" or their
+// plurals. It returns the days named, indexed by time.Weekday, the number of
+// items in the list, whether any of them was plural, and the end of the list.
+func parseWeekdays(s string, start int) (days [7]bool, n int, plural bool, end int) {
+	end = start
+	for {
+		_, eow, w := findSignalNoise(s, start)
+		switch {
+		case w == "weekday" || w == "weekdays":
+			for d := time.Monday; d <= time.Friday; d++ {
+				days[d] = true
+			}
+			plural = plural || w == "weekdays"
+		case w == "weekend" || w == "weekends":
+			days[time.Saturday] = true
+			days[time.Sunday] = true
+			plural = plural || w == "weekends"
+		default:
+			wd, ok := weekdayNameToWeekday[w]
+			if !ok {
+				wd, ok = weekdayNameToWeekday[strings.TrimSuffix(w, "s")]
+				if !ok {
+					return days, n, plural, end
+				}
+				plural = true
+			}
+			days[wd] = true
+		}
+		n++
+		end = eow
+		start = eow
+		if _, eoand, and := findSignalNoise(s, eow); and == "and" {
+			start = eoand
+		}
+	}
+}
+
+// weekdaysWithin returns the parts of r falling on the given days of the
+// week, indexed by time.Weekday.
+func weekdaysWithin(r Range, days [7]bool) RangeSet {
+	var ranges []Range
+	for d := truncateDay(r.start); d.start.Before(r.End()); d = truncateDay(d.start.AddDate(0, 0, 1)) {
+		if !days[d.start.Weekday()] {
+			continue
+		}
+		if dr, ok := d.Intersect(r); ok {
+			ranges = append(ranges, dr)
+		}
+	}
+	return NewRangeSet(ranges...)
+}
+
+// isDay tells whether r covers exactly one calendar day.
+func isDay(r Range) bool {
+	return truncateDay(r.start).Equal(r)
+}
+
 func okYear(y int) bool {
 	return 1000 <= y && y <= 9999
 }
@@ -424,10 +886,7 @@ func inferRange(d date, now time.Time, dir Direction, src string) (Range, bool)
 	switch {
 	// Year month dayOfMonth
 	case d.year != 0 && d.month != 0 && d.dayOfMonth != 0:
-		return Range{
-			time.Date(d.year, d.month, d.dayOfMonth, 0, 0, 0, 0, loc),
-			24 * time.Hour,
-		}, true
+		return truncateDay(time.Date(d.year, d.month, d.dayOfMonth, 0, 0, 0, 0, loc)), true
 
 	// Year month
 	case d.year != 0 && d.month != 0 && d.dayOfMonth == 0:
@@ -487,8 +946,37 @@ func parseInt(s string) (int, bool) {
 	return 0, false
 }
 
+// parseOrdinal parses an ordinal such as "third" or "3rd" from the lower-cased
+// word w.
+func parseOrdinal(w string) (int, bool) {
+	if i, ok := ordinalToInt[w]; ok {
+		return i, true
+	}
+	for _, suffix := range []string{"st", "nd", "rd", "th"} {
+		if digits := strings.TrimSuffix(w, suffix); digits != w {
+			i, err := strconv.Atoi(digits)
+			return i, err == nil && i > 0
+		}
+	}
+	return 0, false
+}
+
+var ordinalToInt = map[string]int{
+	"first":   1,
+	"second":  2,
+	"third":   3,
+	"fourth":  4,
+	"fifth":   5,
+	"sixth":   6,
+	"seventh": 7,
+	"eighth":  8,
+	"ninth":   9,
+	"tenth":   10,
+}
+
 var strToInt = map[string]int{
 	"a":         1,
+	"an":        1,
 	"one":       1,
 	"two":       2,
 	"three":     3,
@@ -526,6 +1014,140 @@ func colorMonthToRange(colorDelta int, monthName string, now time.Time) (Range,
 	return truncateMonth(nextSpecificMonth(now, m).Start().AddDate(colorDelta, 0, 0)), true
 }
 
+// unit is a unit of time such as an hour or a month.
+type unit struct {
+	// add returns t moved forward by n of the unit.
+	add func(t time.Time, n int) time.Time
+
+	// truncate returns the range of the unit containing t.
+	truncate func(t time.Time) Range
+
+	// granularity is the unit as a Granularity.
+	granularity Granularity
+
+	// length is the fixed length of the unit, or 0 if its length depends
+	// on the calendar.
+	length time.Duration
+}
+
+// fits reports whether n of the unit can be added to a time without
+// overflowing a time.Duration.
+func (u unit) fits(n int) bool {
+	if u.length == 0 {
+		return true
+	}
+	max := int64(math.MaxInt64 / u.length)
+	return -max <= int64(n) && int64(n) <= max
+}
+
+// following returns the range of the unit just after p, which should be a
+// range returned by u.truncate.
+func (u unit) following(p Range) Range {
+	end := p.End()
+	next := u.truncate(end)
+	if !next.start.After(p.start) {
+		// end is in an hour that is repeated when daylight saving time
+		// ends, and truncating it went back to the first occurrence.
+		next = Range{end, u.add(end, 1).Sub(end), u.granularity}
+	}
+	return next
+}
+
+var secondUnit = unit{
+	add:         func(t time.Time, n int) time.Time { return t.Add(time.Duration(n) * time.Second) },
+	truncate:    truncateSecond,
+	granularity: Second,
+	length:      time.Second,
+}
+
+var minuteUnit = unit{
+	add:         func(t time.Time, n int) time.Time { return t.Add(time.Duration(n) * time.Minute) },
+	truncate:    truncateMinute,
+	granularity: Minute,
+	length:      time.Minute,
+}
+
+var hourUnit = unit{
+	add:         func(t time.Time, n int) time.Time { return t.Add(time.Duration(n) * time.Hour) },
+	truncate:    truncateHour,
+	granularity: Hour,
+	length:      time.Hour,
+}
+
+var dayUnit = unit{
+	add:         func(t time.Time, n int) time.Time { return t.AddDate(0, 0, n) },
+	truncate:    truncateDay,
+	granularity: Day,
+}
+
+var weekUnit = unit{
+	add:         func(t time.Time, n int) time.Time { return t.AddDate(0, 0, 7*n) },
+	truncate:    truncateWeek,
+	granularity: Week,
+}
+
+// weekUnitFrom returns the unit of a week starting on the day first.
+func weekUnitFrom(first time.Weekday) unit {
+	u := weekUnit
+	u.truncate = func(t time.Time) Range { return truncateWeekFrom(t, first) }
+	return u
+}
+
+var monthUnit = unit{
+	add:         func(t time.Time, n int) time.Time { return t.AddDate(0, n, 0) },
+	truncate:    truncateMonth,
+	granularity: Month,
+}
+
+var quarterUnit = unit{
+	add:         func(t time.Time, n int) time.Time { return t.AddDate(0, 3*n, 0) },
+	truncate:    truncateQuarter,
+	granularity: Quarter,
+}
+
+var halfUnit = unit{
+	add:         func(t time.Time, n int) time.Time { return t.AddDate(0, 6*n, 0) },
+	truncate:    truncateHalf,
+	granularity: Half,
+}
+
+var yearUnit = unit{
+	add:         func(t time.Time, n int) time.Time { return t.AddDate(n, 0, 0) },
+	truncate:    truncateYear,
+	granularity: Year,
+}
+
+var unitNameToUnit = map[string]unit{
+	"second":   secondUnit,
+	"seconds":  secondUnit,
+	"minute":   minuteUnit,
+	"minutes":  minuteUnit,
+	"hour":     hourUnit,
+	"hours":    hourUnit,
+	"day":      dayUnit,
+	"days":     dayUnit,
+	"week":     weekUnit,
+	"weeks":    weekUnit,
+	"month":    monthUnit,
+	"months":   monthUnit,
+	"quarter":  quarterUnit,
+	"quarters": quarterUnit,
+	"year":     yearUnit,
+	"years":    yearUnit,
+}
+
+var granularityToUnit = map[Granularity]unit{
+	Second:  secondUnit,
+	Minute:  minuteUnit,
+	Hour:    hourUnit,
+	Day:     dayUnit,
+	Week:    weekUnit,
+	Month:   monthUnit,
+	Quarter: quarterUnit,
+	Half:    halfUnit,
+	Year:    yearUnit,
+}
+
 var colorToDelta = map[string]int{
 	"white":  0,
 	"red":    1,
@@ -590,17 +1212,39 @@ var monthNameToMonth = map[string]time.Month{
 	"december":  time.December,
 }
 
-var ymdRx = regexp.MustCompile(`(\d{4})[-/](\d{1,2})[-/](\d{1,2})`)
-var dmyRx = regexp.MustCompile(`(\d{1,2})[-/](\d{1,2})[-/](\d{4})`)
+var clock12Rx = regexp.MustCompile(`^(\d{1,2})(?::(\d{2})(?::(\d{2}))?)?(am|pm)$`)
+var clock24Rx = regexp.MustCompile(`^(\d{1,2}):(\d{2})(?::(\d{2}))?$`)
+var hourRx = regexp.MustCompile(`^\d{1,2}$`)
+
+var weekdayNameToWeekday = map[string]time.Weekday{
+	"sun":   time.Sunday,
+	"mon":   time.Monday,
+	"tue":   time.Tuesday,
+	"tues":  time.Tuesday,
+	"wed":   time.Wednesday,
+	"thu":   time.Thursday,
+	"thur":  time.Thursday,
+	"thurs": time.Thursday,
+	"fri":   time.Friday,
+	"sat":   time.Saturday,
+
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
 
 func isSignal(r rune) bool {
-	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '/' || r == '-' || r == '+' || r == ':'
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '/' || r == '-' || r == '–' || r == '—' || r == '+' || r == ':'
 }
 
 func oneWordStrToRange(w string, now time.Time) (Range, bool) {
 	switch {
 	case eq(w, "now"):
-		return Range{now, time.Second}, true
+		return Range{now, time.Second, Second}, true
 	case eq(w, "yesterday"):
 		return truncateDay(now.AddDate(0, 0, -1)), true
 	case eq(w, "today"):
@@ -611,14 +1255,14 @@ func oneWordStrToRange(w string, now time.Time) (Range, bool) {
 	return Range{}, false
 }
 
-func lastThisNextStrToRange(w string, now time.Time) (Range, bool) {
+func lastThisNextStrToRange(w string, now time.Time, o opts) (Range, bool) {
 	switch {
 	case eq(w, "last week"):
-		return truncateWeek(now.AddDate(0, 0, -7)), true
+		return o.truncateWeek(now.AddDate(0, 0, -7)), true
 	case eq(w, "this week"):
-		return truncateWeek(now), true
+		return o.truncateWeek(now), true
 	case eq(w, "next week"):
-		return truncateWeek(now.AddDate(0, 0, 7)), true
+		return o.truncateWeek(now.AddDate(0, 0, 7)), true
 	case eq(w, "last month"):
 		return truncateMonth(now.AddDate(0, -1, 0)), true
 	case eq(w, "this month"):
@@ -631,6 +1275,18 @@ func lastThisNextStrToRange(w string, now time.Time) (Range, bool) {
 		return truncateYear(now), true
 	case eq(w, "next year"):
 		return truncateYear(now.AddDate(1, 0, 0)), true
+	case eq(w, "last quarter"):
+		return truncateQuarter(now.AddDate(0, -3, 0)), true
+	case eq(w, "this quarter"):
+		return truncateQuarter(now), true
+	case eq(w, "next quarter"):
+		return truncateQuarter(now.AddDate(0, 3, 0)), true
+	case eq(w, "last fy"):
+		return truncateFiscalYear(now.AddDate(-1, 0, 0), o.fiscalYearStart), true
+	case eq(w, "this fy"):
+		return truncateFiscalYear(now, o.fiscalYearStart), true
+	case eq(w, "next fy"):
+		return truncateFiscalYear(now.AddDate(1, 0, 0), o.fiscalYearStart), true
 	}
 
 	return Range{}, false
@@ -41,7 +41,7 @@ func Test_parseImplicitRange_monthOnly(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.input, func(t *testing.T) {
 			// future
-			gotRange, parsed, err := parseImplicitRange(tt.input, now, Future)
+			gotRange, parsed, _, err := parseImplicitRange(tt.input, now, newOpts())
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -54,7 +54,7 @@ func Test_parseImplicitRange_monthOnly(t *testing.T) {
 			}
 
 			// past
-			gotRange, parsed, err = parseImplicitRange(tt.input, now, Past)
+			gotRange, parsed, _, err = parseImplicitRange(tt.input, now, newOpts(DefaultToPast))
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -193,7 +193,7 @@ func Test_parseImplicitRange(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			gotR, gotParsed, err := parseImplicitRange(tt.args.s, tt.args.now, tt.args.dir)
+			gotR, gotParsed, _, err := parseImplicitRange(tt.args.s, tt.args.now, newOpts(WithDirection(tt.args.dir)))
 			if (err != nil) != tt.wantErr {
 				t.Errorf("parseImplicitRange() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -208,6 +208,150 @@ func Test_parseImplicitRange(t *testing.T) {
 	}
 }
 
+func Test_parseImplicitRange_timeOfDay(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	today := func(h, m, s int) time.Time {
+		return time.Date(2022, 9, 29, h, m, s, 0, time.UTC)
+	}
+	tomorrow := func(h, m, s int) time.Time {
+		return time.Date(2022, 9, 30, h, m, s, 0, time.UTC)
+	}
+	tests := []struct {
+		input      string
+		wantR      Range
+		wantParsed string
+	}{
+		{"5pm", Range{today(17, 0, 0), time.Hour, Hour}, "5pm"},
+		{"5 PM", Range{today(17, 0, 0), time.Hour, Hour}, "5 PM"},
+		{"12am", Range{today(0, 0, 0), time.Hour, Hour}, "12am"},
+		{"12pm", Range{today(12, 0, 0), time.Hour, Hour}, "12pm"},
+		{"noon", Range{today(12, 0, 0), time.Hour, Hour}, "noon"},
+		{"10:30am", Range{today(10, 30, 0), time.Minute, Minute}, "10:30am"},
+		{"10:30 pm", Range{today(22, 30, 0), time.Minute, Minute}, "10:30 pm"},
+		{"17:45", Range{today(17, 45, 0), time.Minute, Minute}, "17:45"},
+		{"17:45:10", Range{today(17, 45, 10), time.Second, Second}, "17:45:10"},
+		{"1:05:10pm", Range{today(13, 5, 10), time.Second, Second}, "1:05:10pm"},
+		{"5pm UTC+2", Range{time.Date(2022, 9, 29, 17, 0, 0, 0, fixedZone(2)), time.Hour, Hour}, "5pm UTC+2"},
+		{"tomorrow at 5pm", Range{tomorrow(17, 0, 0), time.Hour, Hour}, "tomorrow at 5pm"},
+		{"tomorrow 5pm", Range{tomorrow(17, 0, 0), time.Hour, Hour}, "tomorrow 5pm"},
+		{"tomorrow at noon", Range{tomorrow(12, 0, 0), time.Hour, Hour}, "tomorrow at noon"},
+		{"5pm tomorrow", Range{tomorrow(17, 0, 0), time.Hour, Hour}, "5pm tomorrow"},
+		{"10:15am on march 3", Range{time.Date(2023, 3, 3, 10, 15, 0, 0, time.UTC), time.Minute, Minute}, "10:15am on march 3"},
+		{"March 3, 2022 at 17:45:10", Range{time.Date(2022, 3, 3, 17, 45, 10, 0, time.UTC), time.Second, Second}, "March 3, 2022 at 17:45:10"},
+		{"2 days ago at 8am", Range{time.Date(2022, 9, 27, 8, 0, 0, 0, time.UTC), time.Hour, Hour}, "2 days ago at 8am"},
+		{"2014/3/31 at 23:59 UTC-1", Range{time.Date(2014, 3, 31, 23, 59, 0, 0, fixedZone(-1)), time.Minute, Minute}, "2014/3/31 at 23:59 UTC-1"},
+
+		// Times are only attached to expressions naming a single day.
+		{"5pm next week", Range{today(17, 0, 0), time.Hour, Hour}, "5pm"},
+		{"next week at 5pm", truncateWeek(now.AddDate(0, 0, 7)), "next week"},
+		{"tomorrow at 13pm", truncateDay(now.AddDate(0, 0, 1)), "tomorrow"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			gotR, gotParsed, _, err := parseImplicitRange(tt.input, now, newOpts())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !gotR.Equal(tt.wantR) {
+				t.Errorf("got range %v, want %v", gotR, tt.wantR)
+			}
+			if gotParsed != tt.wantParsed {
+				t.Errorf("parsed %q, want %q", gotParsed, tt.wantParsed)
+			}
+		})
+	}
+}
+
+func Test_parseImplicitRange_weekdays(t *testing.T) {
+	// Thursday
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	day := func(m time.Month, d int) Range {
+		return truncateDay(time.Date(2022, m, d, 0, 0, 0, 0, time.UTC))
+	}
+	tests := []struct {
+		input      string
+		dir        Direction
+		wantR      Range
+		wantParsed string
+	}{
+		{"monday", Future, day(10, 3), "monday"},
+		{"monday", Past, day(9, 26), "monday"},
+		{"Fri", Future, day(9, 30), "Fri"},
+		{"Fri", Past, day(9, 23), "Fri"},
+		{"thursday", Future, day(10, 6), "thursday"},
+		{"thursday", Past, day(9, 22), "thursday"},
+		{"last friday", Future, day(9, 23), "last friday"},
+		{"next tue", Past, day(10, 4), "next tue"},
+		{"this tuesday", Future, day(9, 27), "this tuesday"},
+		{"this saturday", Past, day(10, 1), "this saturday"},
+		{"this sun", Future, day(9, 25), "this sun"},
+		{"wednesday at 5pm", Future, Range{time.Date(2022, 10, 5, 17, 0, 0, 0, time.UTC), time.Hour, Hour}, "wednesday at 5pm"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			gotR, gotParsed, _, err := parseImplicitRange(tt.input, now, newOpts(WithDirection(tt.dir)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !gotR.Equal(tt.wantR) {
+				t.Errorf("got range %v, want %v", gotR, tt.wantR)
+			}
+			if gotParsed != tt.wantParsed {
+				t.Errorf("parsed %q, want %q", gotParsed, tt.wantParsed)
+			}
+		})
+	}
+}
+
+func TestParseRange_granularity(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	tests := []struct {
+		input string
+		want  Granularity
+	}{
+		{"now", Second},
+		{"2006-01-02T15:04:05Z", Second},
+		{"17:45:10", Second},
+		{"30 seconds ago", Second},
+		{"10:30am", Minute},
+		{"in 5 minutes", Minute},
+		{"5pm tomorrow", Hour},
+		{"an hour ago", Hour},
+		{"today", Day},
+		{"friday", Day},
+		{"march 3", Day},
+		{"2014/3/31", Day},
+		{"3 days ago", Day},
+		{"within 3 days", Day},
+		{"last week", Week},
+		{"2 weeks hence", Week},
+		{"march 2023", Month},
+		{"march", Month},
+		{"red october", Month},
+		{"next month", Month},
+		{"this year", Year},
+		{"last quarter", Quarter},
+		{"q3 2022", Quarter},
+		{"h2", Half},
+		{"fy23", Year},
+		{"1999 AD", Year},
+		{"from march 1 to april 1", Day},
+		{"from march to april", Month},
+		{"from last year to 5pm", Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			r, _, err := ParseRange(tt.input, now, Future)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Granularity != tt.want {
+				t.Errorf("granularity = %v, want %v", r.Granularity, tt.want)
+			}
+		})
+	}
+}
+
 func TestParseRange_fail(t *testing.T) {
 	var badCases = []struct {
 		input string
@@ -288,8 +432,21 @@ func TestParseRange_fail(t *testing.T) {
 		{`1999`},
 		{`2008`},
 
+		// Negative counts of units to come.
+		{`in -3 days`},
+		{`within -2 hours`},
+
+		// Counts too big for a time.Duration.
+		{`in 9999999999999 hours`},
+		{`9999999999999 minutes ago`},
+		{`within 9999999999999999 seconds`},
+
 		// Goofy input:
 		{`10:am`},
+		{`13pm`},
+		{`0am`},
+		{`24:00`},
+		{`12:60`},
 	}
 	for _, c := range badCases {
 		t.Run(c.input, func(t *testing.T) {
@@ -346,10 +503,10 @@ func TestParseRange(t *testing.T) {
 				now: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
 				dir: Future,
 			},
-			wantR: RangeFromTimes(
+			wantR: withGranularity(RangeFromTimes(
 				time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC),
 				time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC),
-			),
+			), Month),
 			wantParsed: "from april to may",
 			wantErr:    false,
 		},
@@ -360,13 +517,63 @@ func TestParseRange(t *testing.T) {
 				now: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
 				dir: Future,
 			},
-			wantR: RangeFromTimes(
+			wantR: withGranularity(RangeFromTimes(
 				time.Date(2022, 4, 1, 0, 0, 0, 0, time.UTC),
 				time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC),
-			),
+			), Month),
 			wantParsed: "april to may",
 			wantErr:    false,
 		},
+		{
+			name: "from 9am to 5:30pm",
+			args: args{
+				s:   "from 9am to 5:30pm",
+				now: time.Date(2022, 1, 1, 3, 0, 0, 0, time.UTC),
+			},
+			wantR: withGranularity(RangeFromTimes(
+				time.Date(2022, 1, 1, 9, 0, 0, 0, time.UTC),
+				time.Date(2022, 1, 1, 17, 30, 0, 0, time.UTC),
+			), Minute),
+			wantParsed: "from 9am to 5:30pm",
+		},
+		{
+			name: "from monday to friday, future",
+			args: args{
+				s:   "from monday to friday",
+				now: time.Date(2022, 9, 29, 0, 0, 0, 0, time.UTC),
+				dir: Future,
+			},
+			wantR: withGranularity(RangeFromTimes(
+				time.Date(2022, 10, 3, 0, 0, 0, 0, time.UTC),
+				time.Date(2022, 10, 7, 0, 0, 0, 0, time.UTC),
+			), Day),
+			wantParsed: "from monday to friday",
+		},
+		{
+			name: "monday to friday, past",
+			args: args{
+				s:   "monday to friday",
+				now: time.Date(2022, 9, 29, 0, 0, 0, 0, time.UTC),
+				dir: Past,
+			},
+			wantR: withGranularity(RangeFromTimes(
+				time.Date(2022, 9, 26, 0, 0, 0, 0, time.UTC),
+				time.Date(2022, 9, 30, 0, 0, 0, 0, time.UTC),
+			), Day),
+			wantParsed: "monday to friday",
+		},
+		{
+			name: "from next year to last year stays backwards",
+			args: args{
+				s:   "from next year to last year",
+				now: time.Date(2022, 9, 29, 0, 0, 0, 0, time.UTC),
+			},
+			wantR: withGranularity(RangeFromTimes(
+				time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+				time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			), Year),
+			wantParsed: "from next year to last year",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -385,6 +592,132 @@ func TestParseRange(t *testing.T) {
 	}
 }
 
+func TestParseRangeSet(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	// days returns the range from the start of day d1 to the start of day d2
+	// in the given month.
+	days := func(y int, m time.Month, d1, d2 int) Range {
+		return RangeFromTimes(time.Date(y, m, d1, 0, 0, 0, 0, time.UTC), time.Date(y, m, d2, 0, 0, 0, 0, time.UTC))
+	}
+	tests := []struct {
+		input      string
+		dir        Direction
+		want       []Range
+		wantParsed string
+	}{
+		{
+			input: "weekdays in march",
+			want: []Range{
+				days(2023, time.March, 1, 4),
+				days(2023, time.March, 6, 11),
+				days(2023, time.March, 13, 18),
+				days(2023, time.March, 20, 25),
+				days(2023, time.March, 27, 32),
+			},
+			wantParsed: "weekdays in march",
+		},
+		{
+			input: "weekdays in march",
+			dir:   Past,
+			want: []Range{
+				days(2022, time.March, 1, 5),
+				days(2022, time.March, 7, 12),
+				days(2022, time.March, 14, 19),
+				days(2022, time.March, 21, 26),
+				days(2022, time.March, 28, 32),
+			},
+			wantParsed: "weekdays in march",
+		},
+		{
+			input: "Mondays and Wednesdays next month",
+			want: []Range{
+				days(2022, time.October, 3, 4),
+				days(2022, time.October, 5, 6),
+				days(2022, time.October, 10, 11),
+				days(2022, time.October, 12, 13),
+				days(2022, time.October, 17, 18),
+				days(2022, time.October, 19, 20),
+				days(2022, time.October, 24, 25),
+				days(2022, time.October, 26, 27),
+				days(2022, time.October, 31, 32),
+			},
+			wantParsed: "Mondays and Wednesdays next month",
+		},
+		{
+			input: "weekends during october 2022, at the lake",
+			want: []Range{
+				days(2022, time.October, 1, 3),
+				days(2022, time.October, 8, 10),
+				days(2022, time.October, 15, 17),
+				days(2022, time.October, 22, 24),
+				days(2022, time.October, 29, 31),
+			},
+			wantParsed: "weekends during october 2022",
+		},
+		{
+			input: "tuesdays, thursdays and saturdays of this week",
+			want: []Range{
+				days(2022, time.September, 27, 28),
+				days(2022, time.September, 29, 30),
+				days(2022, time.October, 1, 2),
+			},
+			wantParsed: "tuesdays, thursdays and saturdays of this week",
+		},
+		{
+			input: "monday and wednesday next week",
+			want: []Range{
+				days(2022, time.October, 3, 4),
+				days(2022, time.October, 5, 6),
+			},
+			wantParsed: "monday and wednesday next week",
+		},
+		{
+			input:      "march 3 and march 5",
+			want:       []Range{days(2023, time.March, 3, 4), days(2023, time.March, 5, 6)},
+			wantParsed: "march 3 and march 5",
+		},
+		{
+			input:      "today and tomorrow",
+			want:       []Range{days(2022, time.September, 29, 31)},
+			wantParsed: "today and tomorrow",
+		},
+		{
+			input:      "monday and friday",
+			want:       []Range{days(2022, time.October, 3, 4), days(2022, time.September, 30, 31)},
+			wantParsed: "monday and friday",
+		},
+		{
+			input:      "tomorrow and then some",
+			want:       []Range{days(2022, time.September, 30, 31)},
+			wantParsed: "tomorrow",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, parsed, err := ParseRangeSet(tt.input, now, tt.dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := NewRangeSet(tt.want...); !got.Equal(want) {
+				t.Errorf("ParseRangeSet() = %v, want %v", got.Ranges(), want.Ranges())
+			}
+			if parsed != tt.wantParsed {
+				t.Errorf("ParseRangeSet() parsed = %q, want %q", parsed, tt.wantParsed)
+			}
+		})
+	}
+}
+
+func TestParseRangeSet_fail(t *testing.T) {
+	for _, s := range []string{"", "mondays", "weekdays and stuff", "and march 3"} {
+		t.Run(s, func(t *testing.T) {
+			if _, _, err := ParseRangeSet(s, time.Time{}, Future); err == nil {
+				t.Error("parsing succeeded, want failure")
+			}
+		})
+	}
+}
+
 func TestErrNoConnectorFound_Error(t *testing.T) {
 	type fields struct {
 		ParsedStart    string
@@ -416,3 +749,9 @@ func TestErrNoConnectorFound_Error(t *testing.T) {
 		})
 	}
 }
+
+// withGranularity returns r with its granularity set to g.
+func withGranularity(r Range, g Granularity) Range {
+	r.Granularity = g
+	return r
+}
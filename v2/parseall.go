@@ -0,0 +1,332 @@
+package anytime
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Interpretation is one way of reading an expression, as returned by
+// ParseAll.
+type Interpretation struct {
+	// Range is the range that the expression means when read this way.
+	Range Range
+
+	// Parsed is the prefix of the expression that was parsed.
+	Parsed string
+
+	// Kind is the kind of expression that Parsed is.
+	Kind Kind
+
+	// Rule says how the expression was read to get Range, as in "past",
+	// "month first" or "China Standard Time", with several of them
+	// separated by commas. It is empty if the expression can only be read
+	// one way.
+	Rule string
+
+	// Score is the confidence in this interpretation, from 0 to 1. The
+	// scores of the interpretations of an expression add up to at most 1,
+	// with the rest being the confidence that it is not a date at all, as
+	// with "may" in "we may go".
+	Score float64
+}
+
+// reading is a choice of how to read expressions that could be read more
+// than one way, such as in the future or in the past.
+type reading struct {
+	// name is the name of the choice, as in "past".
+	name string
+
+	// weight is how likely the choice is relative to the others for the
+	// same thing.
+	weight float64
+
+	// apply changes the options to make the choice.
+	apply func(o *opts)
+}
+
+// bareNumberReading is a way of reading a number on its own, as in "12".
+type bareNumberReading int
+
+const (
+	// noBareNumbers leaves numbers on their own unread.
+	noBareNumbers bareNumberReading = iota
+
+	// bareDay reads them as days of the month.
+	bareDay
+
+	// bareMonth reads them as months.
+	bareMonth
+
+	// bareHour reads them as hours on the 24-hour clock.
+	bareHour
+)
+
+// bareNumberRx matches a number of one or two digits.
+var bareNumberRx = regexp.MustCompile(`^\d{1,2}$`)
+
+// nonDateWords are words that Parse takes to be dates but that, written in
+// lower case, are more often used for something else, as in "we may go" or
+// "sun and sand".
+var nonDateWords = map[string]bool{
+	"may":   true,
+	"march": true,
+	"mar":   true,
+	"sun":   true,
+	"sat":   true,
+	"wed":   true,
+}
+
+// ParseAll is like Parse but returns every plausible interpretation of the
+// expression at the beginning of s, most likely first, rather than picking
+// one. It reads expressions like "december" both in the future and in the
+// past, numeric dates like "03/04/2022" and "3/4" both day first and month
+// first, numbers on their own like "12" as days of the month, months and
+// hours, and time zones like "CST" as each of the zones they may stand for.
+// The choices given by the options are taken to be more likely than the
+// others.
+func ParseAll(s string, now time.Time, options ...func(o *opts)) ([]Interpretation, error) {
+	o := newOpts(options...)
+	dims := [][]reading{directionReadings(o), dateOrderReadings(o)}
+	if numbers := bareNumberReadings(s); numbers != nil {
+		dims = append(dims, numbers)
+	}
+	if zones := zoneReadings(s, now, o); zones != nil {
+		dims = append(dims, zones)
+	}
+
+	type group struct {
+		Interpretation
+		weight float64
+		names  []map[string]bool
+	}
+	var groups []*group
+	// seen has the names of the choices in each dimension that gave a
+	// range.
+	seen := make([]map[string]bool, len(dims))
+	for i := range seen {
+		seen[i] = map[string]bool{}
+	}
+	var total float64
+	var firstErr error
+	for _, rs := range combineReadings(dims) {
+		o2 := o
+		weight := 1.0
+		for _, rd := range rs {
+			rd.apply(&o2)
+			weight *= rd.weight
+		}
+		r, parsed, kind, err := parseRange(s, now, o2)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		total += weight
+		var g *group
+		for _, h := range groups {
+			if h.Range.Equal(r) && h.Parsed == parsed && h.Kind == kind {
+				g = h
+				break
+			}
+		}
+		if g == nil {
+			g = &group{Interpretation: Interpretation{Range: r, Parsed: parsed, Kind: kind}}
+			g.names = make([]map[string]bool, len(dims))
+			for i := range g.names {
+				g.names[i] = map[string]bool{}
+			}
+			groups = append(groups, g)
+		}
+		g.weight += weight
+		for i, rd := range rs {
+			g.names[i][rd.name] = true
+			seen[i][rd.name] = true
+		}
+	}
+	if len(groups) == 0 {
+		return nil, firstErr
+	}
+
+	var is []Interpretation
+	for _, g := range groups {
+		// Only mention the choices that made a difference.
+		var rule []string
+		for i, dim := range dims {
+			if len(g.names[i]) == len(seen[i]) {
+				continue
+			}
+			var names []string
+			for _, rd := range dim {
+				if g.names[i][rd.name] {
+					names = append(names, rd.name)
+				}
+			}
+			rule = append(rule, strings.Join(names, " or "))
+		}
+		g.Rule = strings.Join(rule, ", ")
+		g.Score = g.weight / total
+		if nonDateWords[strings.TrimSpace(g.Parsed)] {
+			g.Score /= 2
+		}
+		is = append(is, g.Interpretation)
+	}
+	sort.SliceStable(is, func(i, j int) bool {
+		return is[i].Score > is[j].Score
+	})
+	return is, nil
+}
+
+// directionReadings returns the readings of expressions that could be in the
+// future or in the past, favoring o.dir.
+func directionReadings(o opts) []reading {
+	future := reading{"future", 1, WithDirection(Future)}
+	past := reading{"past", 1, WithDirection(Past)}
+	if o.dir == Past {
+		past.weight = 2
+		return []reading{past, future}
+	}
+	future.weight = 2
+	return []reading{future, past}
+}
+
+// dateOrderReadings returns the readings of numeric dates that could be day
+// or month first, favoring o.dateOrder if it is DMY or MDY.
+func dateOrderReadings(o opts) []reading {
+	dmy := reading{"day first", 1, WithDateOrder(DMY)}
+	mdy := reading{"month first", 1, WithDateOrder(MDY)}
+	switch o.dateOrder {
+	case DMY:
+		dmy.weight = 2
+	case MDY:
+		mdy.weight = 2
+		return []reading{mdy, dmy}
+	}
+	return []reading{dmy, mdy}
+}
+
+// bareNumberReadings returns the readings of a number on its own at the
+// beginning of s, as in "12", as whichever of a day of the month, a month and
+// an hour it can be, or nil if there is no such number.
+func bareNumberReadings(s string) []reading {
+	_, _, w := findSignalNoise(s, 0)
+	if !bareNumberRx.MatchString(w) {
+		return nil
+	}
+	n, _ := strconv.Atoi(w)
+	var rs []reading
+	if 1 <= n && n <= 31 {
+		rs = append(rs, reading{"day of the month", 1, withBareNumbers(bareDay)})
+	}
+	if 1 <= n && n <= 12 {
+		rs = append(rs, reading{"month", 1, withBareNumbers(bareMonth)})
+	}
+	if n <= 23 {
+		rs = append(rs, reading{"hour", 1, withBareNumbers(bareHour)})
+	}
+	return rs
+}
+
+// withBareNumbers sets the option to read numbers on their own as given by
+// b.
+func withBareNumbers(b bareNumberReading) func(o *opts) {
+	return func(o *opts) {
+		o.bareNumber = b
+	}
+}
+
+// parseBareNumber parses a number on its own at the first word at or after
+// index start of s, as in "12", reading it as o.bareNumber says. A day of the
+// month or a month is the next or last one after or before now, as o.dir
+// says, and an hour is on the day of now. It returns the range, the index
+// just past the number and whether one was found.
+func parseBareNumber(s string, start int, now time.Time, o opts) (Range, int, bool) {
+	_, eow, w := findSignalNoise(s, start)
+	if o.bareNumber == noBareNumbers || !bareNumberRx.MatchString(w) {
+		return Range{}, 0, false
+	}
+	n, _ := strconv.Atoi(w)
+	switch o.bareNumber {
+	case bareDay:
+		if n < 1 || n > 31 {
+			return Range{}, 0, false
+		}
+		step := 1
+		if o.dir == Past {
+			step = -1
+		}
+		// Some month within a year has every day of the month.
+		for i := 0; i <= 12; i++ {
+			first := time.Date(now.Year(), now.Month()+time.Month(step*i), 1, 0, 0, 0, 0, now.Location())
+			if !okDate(first.Year(), int(first.Month()), n) {
+				continue
+			}
+			d := first.AddDate(0, 0, n-1)
+			if (o.dir == Past && d.Before(truncateDay(now).start)) || (o.dir != Past && d.After(now)) {
+				return truncateDay(d), eow, true
+			}
+		}
+	case bareMonth:
+		if 1 <= n && n <= 12 {
+			r, _ := inferRange(date{month: time.Month(n)}, now, o.dir, w)
+			return r, eow, true
+		}
+	case bareHour:
+		if n <= 23 {
+			return clock{hour: n, granularity: Hour}.on(truncateDay(now)), eow, true
+		}
+	}
+	return Range{}, 0, false
+}
+
+// zoneReadings returns the readings of the first ambiguous time zone
+// abbreviation in the expression at the beginning of s, favoring the zone
+// picked by o.zonePolicy, or nil if there is no such abbreviation.
+func zoneReadings(s string, now time.Time, o opts) []reading {
+	policy := o.zonePolicy
+	if o.dateOrder != DMY && o.dateOrder != MDY {
+		// Get past any ambiguous numeric date to the zone.
+		o.dateOrder = DMY
+	}
+	var abbrev string
+	var zones []Zone
+	o.zonePolicy = func(a string, zs []Zone) (*time.Location, bool) {
+		if zones == nil {
+			abbrev, zones = a, zs
+		}
+		return FirstZone(a, zs)
+	}
+	parseRange(s, now, o)
+	if zones == nil {
+		return nil
+	}
+	picked, _ := policy(abbrev, zones)
+	var rs []reading
+	for _, z := range zones {
+		rd := reading{z.Name, 1, WithZonePolicy(PreferZones(z.Name))}
+		if z.Location == picked {
+			rd.weight = 2
+		}
+		rs = append(rs, rd)
+	}
+	return rs
+}
+
+// combineReadings returns every combination of one reading from each of
+// dims.
+func combineReadings(dims [][]reading) [][]reading {
+	combos := [][]reading{nil}
+	for _, dim := range dims {
+		var next [][]reading
+		for _, c := range combos {
+			for _, rd := range dim {
+				next = append(next, append(append([]reading(nil), c...), rd))
+			}
+		}
+		combos = next
+	}
+	return combos
+}
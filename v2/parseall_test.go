@@ -0,0 +1,99 @@
+package anytime
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestParseAll(t *testing.T) {
+	// Thursday
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	type want struct {
+		start time.Time
+		rule  string
+		score float64
+	}
+	tests := []struct {
+		input   string
+		options []func(o *opts)
+		want    []want
+	}{
+		{"tomorrow", nil, []want{
+			{time.Date(2022, 9, 30, 0, 0, 0, 0, time.UTC), "", 1},
+		}},
+		{"friday", nil, []want{
+			{time.Date(2022, 9, 30, 0, 0, 0, 0, time.UTC), "future", 2.0 / 3},
+			{time.Date(2022, 9, 23, 0, 0, 0, 0, time.UTC), "past", 1.0 / 3},
+		}},
+		{"December", []func(o *opts){DefaultToPast}, []want{
+			{time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC), "past", 2.0 / 3},
+			{time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC), "future", 1.0 / 3},
+		}},
+		{"may", nil, []want{
+			{time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), "future", 1.0 / 3},
+			{time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC), "past", 1.0 / 6},
+		}},
+		{"03/04/2022", nil, []want{
+			{time.Date(2022, 4, 3, 0, 0, 0, 0, time.UTC), "day first", 0.5},
+			{time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC), "month first", 0.5},
+		}},
+		{"03.04.2022", []func(o *opts){WithDateOrder(MDY)}, []want{
+			{time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC), "month first", 2.0 / 3},
+			{time.Date(2022, 4, 3, 0, 0, 0, 0, time.UTC), "day first", 1.0 / 3},
+		}},
+		{"3/4", nil, []want{
+			{time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC), "future, day first", 1.0 / 3},
+			{time.Date(2023, 3, 4, 0, 0, 0, 0, time.UTC), "future, month first", 1.0 / 3},
+			{time.Date(2022, 4, 3, 0, 0, 0, 0, time.UTC), "past, day first", 1.0 / 6},
+			{time.Date(2022, 3, 4, 0, 0, 0, 0, time.UTC), "past, month first", 1.0 / 6},
+		}},
+		{"12", nil, []want{
+			{time.Date(2022, 9, 29, 12, 0, 0, 0, time.UTC), "hour", 1.0 / 3},
+			{time.Date(2022, 10, 12, 0, 0, 0, 0, time.UTC), "future, day of the month", 2.0 / 9},
+			{time.Date(2022, 12, 1, 0, 0, 0, 0, time.UTC), "future, month", 2.0 / 9},
+			{time.Date(2022, 9, 12, 0, 0, 0, 0, time.UTC), "past, day of the month", 1.0 / 9},
+			{time.Date(2021, 12, 1, 0, 0, 0, 0, time.UTC), "past, month", 1.0 / 9},
+		}},
+		{"31/03/2022", nil, []want{
+			{time.Date(2022, 3, 31, 0, 0, 0, 0, time.UTC), "", 1},
+		}},
+		{"5pm CST", nil, []want{
+			{time.Date(2022, 9, 29, 17, 0, 0, 0, fixedZone(-6)), "Central Standard Time", 0.5},
+			{time.Date(2022, 9, 29, 17, 0, 0, 0, fixedZone(8)), "China Standard Time", 0.25},
+			{time.Date(2022, 9, 29, 17, 0, 0, 0, fixedZone(-5)), "Cuba Standard Time", 0.25},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseAll(tt.input, now, tt.options...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %d interpretations, want %d: %+v", len(got), len(tt.want), got)
+			}
+			for i, w := range tt.want {
+				g := got[i]
+				if !g.Range.Start().Equal(w.start) || g.Rule != w.rule || math.Abs(g.Score-w.score) > 1e-9 {
+					t.Errorf("interpretation %d is %v %q %v, want %v %q %v", i, g.Range.Start(), g.Rule, g.Score, w.start, w.rule, w.score)
+				}
+				if g.Parsed != tt.input {
+					t.Errorf("interpretation %d parsed %q, want %q", i, g.Parsed, tt.input)
+				}
+			}
+		})
+	}
+}
+
+func TestParseAll_fail(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	for _, s := range []string{"", "45", "hello"} {
+		t.Run(s, func(t *testing.T) {
+			got, err := ParseAll(s, now)
+			if err == nil {
+				t.Errorf("got %+v, want error", got)
+			}
+		})
+	}
+}
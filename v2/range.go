@@ -12,6 +12,59 @@ type RangeFunc func(ref time.Time, dir Direction) Range
 type Range struct {
 	start    time.Time
 	Duration time.Duration
+
+	// Granularity is the unit of time in which the range was expressed, for
+	// example Month for "march 2023" or Day for "from march 1 to april 1".
+	Granularity Granularity
+}
+
+// Granularity is a unit of time in which a Range can be expressed.
+type Granularity int
+
+// Granularities, from finest to coarsest.
+const (
+	Second Granularity = iota
+	Minute
+	Hour
+	Day
+	Week
+	Month
+	Quarter
+	Half
+	Year
+)
+
+// String returns the name of the granularity.
+func (g Granularity) String() string {
+	switch g {
+	case Second:
+		return "second"
+	case Minute:
+		return "minute"
+	case Hour:
+		return "hour"
+	case Day:
+		return "day"
+	case Week:
+		return "week"
+	case Month:
+		return "month"
+	case Quarter:
+		return "quarter"
+	case Half:
+		return "half"
+	case Year:
+		return "year"
+	}
+	return "unknown"
+}
+
+// finer returns whichever of g and h is the finer granularity.
+func finer(g, h Granularity) Granularity {
+	if h < g {
+		return h
+	}
+	return g
 }
 
 // Start is when the range begins, inclusive.
@@ -24,12 +77,175 @@ func (r Range) End() time.Time {
 	return r.start.Add(r.Duration)
 }
 
-// Equal returns true if the two ranges are equal.
+// Equal returns true if the two ranges cover the same interval. Their
+// granularities are not compared.
 func (r Range) Equal(other Range) bool {
 	return r.start.Equal(other.start) && r.Duration == other.Duration
 }
 
-// RangeFromTimes returns a Range from two times.
+// RangeFromTimes returns a Range from two times, with Second granularity.
 func RangeFromTimes(start, end time.Time) Range {
-	return Range{start, end.Sub(start)}
+	return Range{start, end.Sub(start), Second}
+}
+
+// The following methods treat ranges as half-open intervals [Start, End).
+// A range whose Duration is zero or negative, such as the result of parsing
+// "from next year to last year", is empty: it contains no times and overlaps
+// no other range.
+
+// empty reports whether r contains no times.
+func (r Range) empty() bool {
+	return r.Duration <= 0
+}
+
+// Contains reports whether t is within r.
+func (r Range) Contains(t time.Time) bool {
+	return !r.empty() && !t.Before(r.start) && t.Before(r.End())
+}
+
+// ContainsRange reports whether all of other lies within r. An empty range
+// is contained by r if its start is within r or at r's end.
+func (r Range) ContainsRange(other Range) bool {
+	end := other.End()
+	if other.empty() {
+		end = other.start
+	}
+	return !other.start.Before(r.start) && !end.After(r.End())
+}
+
+// Overlaps reports whether r and other have any times in common.
+func (r Range) Overlaps(other Range) bool {
+	_, ok := r.Intersect(other)
+	return ok
+}
+
+// Intersect returns the range of times in both r and other, with the finer
+// of their granularities. It returns false if the ranges do not overlap.
+func (r Range) Intersect(other Range) (Range, bool) {
+	if r.empty() || other.empty() {
+		return Range{}, false
+	}
+	start := later(r.start, other.start)
+	end := earlier(r.End(), other.End())
+	if !end.After(start) {
+		return Range{}, false
+	}
+	return Range{start, end.Sub(start), finer(r.Granularity, other.Granularity)}, true
+}
+
+// Union returns the smallest range covering both r and other, with the finer
+// of their granularities. It returns false if the ranges neither overlap nor
+// touch, since their union would not be a single range. An empty range
+// contributes nothing to the union.
+func (r Range) Union(other Range) (Range, bool) {
+	if other.empty() {
+		return r, true
+	}
+	if r.empty() {
+		return other, true
+	}
+	if later(r.start, other.start).After(earlier(r.End(), other.End())) {
+		return Range{}, false
+	}
+	start := earlier(r.start, other.start)
+	end := later(r.End(), other.End())
+	return Range{start, end.Sub(start), finer(r.Granularity, other.Granularity)}, true
+}
+
+// Gap returns the range between r and other, with the finer of their
+// granularities. It returns false if either range is empty or if the ranges
+// overlap or touch.
+func (r Range) Gap(other Range) (Range, bool) {
+	if r.empty() || other.empty() {
+		return Range{}, false
+	}
+	start := earlier(r.End(), other.End())
+	end := later(r.start, other.start)
+	if !end.After(start) {
+		return Range{}, false
+	}
+	return Range{start, end.Sub(start), finer(r.Granularity, other.Granularity)}, true
+}
+
+// Shift returns r moved later in time by d, or earlier if d is negative.
+func (r Range) Shift(d time.Duration) Range {
+	return Range{r.start.Add(d), r.Duration, r.Granularity}
+}
+
+// Clamp returns t limited to the bounds of r: Start if t is before r, End if
+// t is at or after the end of r, and t otherwise. For an empty range it
+// returns Start.
+func (r Range) Clamp(t time.Time) time.Time {
+	if r.empty() || t.Before(r.start) {
+		return r.start
+	}
+	if end := r.End(); !t.Before(end) {
+		return end
+	}
+	return t
+}
+
+// Split divides r into the calendar periods of the given granularity that it
+// overlaps, such as the days of a month for Day, using calendar arithmetic so
+// that days stay aligned across changes to daylight saving time. The first
+// and last pieces are cut short if r does not begin or end on a boundary. Each
+// piece has step as its granularity. Split returns nil if r is empty or if
+// step has no calendar unit. Weeks start on the day given by the
+// WeekStartsOn option, or on Sunday.
+func (r Range) Split(step Granularity, options ...func(o *opts)) []Range {
+	var rs []Range
+	r.split(step, newOpts(options...), func(p Range) bool {
+		rs = append(rs, p)
+		return true
+	})
+	return rs
+}
+
+// Days splits r into the days it overlaps.
+func (r Range) Days() []Range {
+	return r.Split(Day)
+}
+
+// Weeks splits r into the weeks it overlaps, starting on the day given by
+// the WeekStartsOn option, or on Sunday.
+func (r Range) Weeks(options ...func(o *opts)) []Range {
+	return r.Split(Week, options...)
+}
+
+// Months splits r into the months it overlaps.
+func (r Range) Months() []Range {
+	return r.Split(Month)
+}
+
+// split calls yield with each of the pieces Split would return with the
+// options o, stopping early if yield returns false.
+func (r Range) split(step Granularity, o opts, yield func(Range) bool) {
+	_, ok := granularityToUnit[step]
+	u := o.unit(step)
+	if !ok || r.empty() {
+		return
+	}
+	end := r.End()
+	for p := u.truncate(r.start); p.start.Before(end); p = u.following(p) {
+		start := later(p.start, r.start)
+		if !yield(Range{start, earlier(p.End(), end).Sub(start), step}) {
+			return
+		}
+	}
+}
+
+// earlier returns whichever of s and t comes first.
+func earlier(s, t time.Time) time.Time {
+	if t.Before(s) {
+		return t
+	}
+	return s
+}
+
+// later returns whichever of s and t comes last.
+func later(s, t time.Time) time.Time {
+	if t.After(s) {
+		return t
+	}
+	return s
 }
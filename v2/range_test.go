@@ -0,0 +1,433 @@
+package anytime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGranularity_String(t *testing.T) {
+	tests := []struct {
+		g    Granularity
+		want string
+	}{
+		{Second, "second"},
+		{Minute, "minute"},
+		{Hour, "hour"},
+		{Day, "day"},
+		{Week, "week"},
+		{Month, "month"},
+		{Quarter, "quarter"},
+		{Half, "half"},
+		{Year, "year"},
+		{Granularity(-1), "unknown"},
+	}
+	for _, tt := range tests {
+		if got := tt.g.String(); got != tt.want {
+			t.Errorf("Granularity(%d).String() = %q, want %q", tt.g, got, tt.want)
+		}
+	}
+}
+
+var t0 = time.Date(2022, 9, 29, 0, 0, 0, 0, time.UTC)
+
+// hours returns the range from hour a to hour b after t0. It is empty if
+// b <= a.
+func hours(a, b int) Range {
+	return RangeFromTimes(t0.Add(time.Duration(a)*time.Hour), t0.Add(time.Duration(b)*time.Hour))
+}
+
+func at(h int) time.Time {
+	return t0.Add(time.Duration(h) * time.Hour)
+}
+
+func TestRange_Contains(t *testing.T) {
+	tests := []struct {
+		name string
+		r    Range
+		t    time.Time
+		want bool
+	}{
+		{"before", hours(1, 3), at(0), false},
+		{"at start", hours(1, 3), at(1), true},
+		{"inside", hours(1, 3), at(2), true},
+		{"just before end", hours(1, 3), at(3).Add(-time.Nanosecond), true},
+		{"at end", hours(1, 3), at(3), false},
+		{"after", hours(1, 3), at(4), false},
+		{"zero duration at start", hours(1, 1), at(1), false},
+		{"negative duration at start", hours(3, 1), at(3), false},
+		{"negative duration between", hours(3, 1), at(2), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Contains(tt.t); got != tt.want {
+				t.Errorf("Contains() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRange_ContainsRange(t *testing.T) {
+	tests := []struct {
+		name  string
+		r     Range
+		other Range
+		want  bool
+	}{
+		{"same", hours(1, 5), hours(1, 5), true},
+		{"inside", hours(1, 5), hours(2, 4), true},
+		{"sharing start", hours(1, 5), hours(1, 2), true},
+		{"sharing end", hours(1, 5), hours(4, 5), true},
+		{"sticking out before", hours(1, 5), hours(0, 2), false},
+		{"sticking out after", hours(1, 5), hours(4, 6), false},
+		{"covering", hours(1, 5), hours(0, 6), false},
+		{"disjoint", hours(1, 5), hours(6, 7), false},
+		{"zero duration inside", hours(1, 5), hours(3, 3), true},
+		{"zero duration at end", hours(1, 5), hours(5, 5), true},
+		{"zero duration after", hours(1, 5), hours(6, 6), false},
+		{"negative duration inside", hours(1, 5), hours(4, 2), true},
+		{"negative duration outside", hours(1, 5), hours(7, 6), false},
+		{"non-empty in zero duration", hours(3, 3), hours(3, 4), false},
+		{"zero duration in itself", hours(3, 3), hours(3, 3), true},
+		{"non-empty in negative duration", hours(5, 1), hours(2, 4), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.ContainsRange(tt.other); got != tt.want {
+				t.Errorf("ContainsRange() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRange_Intersect(t *testing.T) {
+	tests := []struct {
+		name   string
+		r      Range
+		other  Range
+		want   Range
+		wantOK bool
+	}{
+		{"same", hours(1, 5), hours(1, 5), hours(1, 5), true},
+		{"inside", hours(1, 5), hours(2, 4), hours(2, 4), true},
+		{"overlapping start", hours(1, 5), hours(0, 2), hours(1, 2), true},
+		{"overlapping end", hours(1, 5), hours(4, 6), hours(4, 5), true},
+		{"covering", hours(1, 5), hours(0, 6), hours(1, 5), true},
+		{"adjacent after", hours(1, 5), hours(5, 6), Range{}, false},
+		{"adjacent before", hours(1, 5), hours(0, 1), Range{}, false},
+		{"disjoint", hours(1, 5), hours(6, 7), Range{}, false},
+		{"zero duration inside", hours(1, 5), hours(3, 3), Range{}, false},
+		{"negative duration inside", hours(1, 5), hours(4, 2), Range{}, false},
+		{"inside negative duration", hours(5, 1), hours(2, 4), Range{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.r.Intersect(tt.other)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("Intersect() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+			if ok2 := tt.r.Overlaps(tt.other); ok2 != tt.wantOK {
+				t.Errorf("Overlaps() = %v, want %v", ok2, tt.wantOK)
+			}
+			if ok2 := tt.other.Overlaps(tt.r); ok2 != tt.wantOK {
+				t.Errorf("Overlaps() reversed = %v, want %v", ok2, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRange_Union(t *testing.T) {
+	tests := []struct {
+		name   string
+		r      Range
+		other  Range
+		want   Range
+		wantOK bool
+	}{
+		{"same", hours(1, 5), hours(1, 5), hours(1, 5), true},
+		{"inside", hours(1, 5), hours(2, 4), hours(1, 5), true},
+		{"overlapping start", hours(1, 5), hours(0, 2), hours(0, 5), true},
+		{"overlapping end", hours(1, 5), hours(4, 6), hours(1, 6), true},
+		{"adjacent after", hours(1, 5), hours(5, 6), hours(1, 6), true},
+		{"adjacent before", hours(1, 5), hours(0, 1), hours(0, 5), true},
+		{"disjoint", hours(1, 5), hours(6, 7), Range{}, false},
+		{"zero duration far away", hours(1, 5), hours(9, 9), hours(1, 5), true},
+		{"negative duration far away", hours(1, 5), hours(9, 8), hours(1, 5), true},
+		{"onto zero duration", hours(9, 9), hours(1, 5), hours(1, 5), true},
+		{"onto negative duration", hours(9, 8), hours(1, 5), hours(1, 5), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.r.Union(tt.other)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("Union() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRange_Gap(t *testing.T) {
+	tests := []struct {
+		name   string
+		r      Range
+		other  Range
+		want   Range
+		wantOK bool
+	}{
+		{"after", hours(1, 3), hours(5, 6), hours(3, 5), true},
+		{"before", hours(5, 6), hours(1, 3), hours(3, 5), true},
+		{"adjacent", hours(1, 3), hours(3, 6), Range{}, false},
+		{"overlapping", hours(1, 4), hours(3, 6), Range{}, false},
+		{"inside", hours(1, 6), hours(3, 4), Range{}, false},
+		{"zero duration", hours(1, 3), hours(5, 5), Range{}, false},
+		{"negative duration", hours(6, 5), hours(1, 3), Range{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.r.Gap(tt.other)
+			if ok != tt.wantOK || !got.Equal(tt.want) {
+				t.Errorf("Gap() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRange_Shift(t *testing.T) {
+	tests := []struct {
+		name string
+		r    Range
+		d    time.Duration
+		want Range
+	}{
+		{"forward", hours(1, 3), 2 * time.Hour, hours(3, 5)},
+		{"backward", hours(1, 3), -2 * time.Hour, hours(-1, 1)},
+		{"zero", hours(1, 3), 0, hours(1, 3)},
+		{"zero duration", hours(1, 1), time.Hour, hours(2, 2)},
+		{"negative duration", hours(3, 1), time.Hour, hours(4, 2)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Shift(tt.d); !got.Equal(tt.want) {
+				t.Errorf("Shift() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRange_Clamp(t *testing.T) {
+	tests := []struct {
+		name string
+		r    Range
+		t    time.Time
+		want time.Time
+	}{
+		{"before", hours(1, 3), at(0), at(1)},
+		{"at start", hours(1, 3), at(1), at(1)},
+		{"inside", hours(1, 3), at(2), at(2)},
+		{"at end", hours(1, 3), at(3), at(3)},
+		{"after", hours(1, 3), at(4), at(3)},
+		{"zero duration", hours(1, 1), at(4), at(1)},
+		{"negative duration", hours(3, 1), at(2), at(3)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.r.Clamp(tt.t); !got.Equal(tt.want) {
+				t.Errorf("Clamp() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRange_algebraGranularity(t *testing.T) {
+	day := Range{t0, 24 * time.Hour, Day}
+	hour := Range{t0.Add(time.Hour), time.Hour, Hour}
+	if got, _ := day.Intersect(hour); got.Granularity != Hour {
+		t.Errorf("Intersect() granularity = %v, want %v", got.Granularity, Hour)
+	}
+	if got, _ := day.Union(hour); got.Granularity != Hour {
+		t.Errorf("Union() granularity = %v, want %v", got.Granularity, Hour)
+	}
+	if got := day.Shift(time.Hour); got.Granularity != Day {
+		t.Errorf("Shift() granularity = %v, want %v", got.Granularity, Day)
+	}
+}
+
+func TestRange_Split(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	date := func(y int, m time.Month, d int) time.Time {
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	// estHalfPastOne is the second 1:30am on the day daylight saving time
+	// ends, an hour after the first.
+	estHalfPastOne := time.Date(2022, 11, 6, 1, 30, 0, 0, ny).Add(time.Hour)
+	tests := []struct {
+		name      string
+		r         Range
+		step      Granularity
+		wantN     int
+		wantFirst Range
+		wantLast  Range
+	}{
+		{
+			name:      "days of jan 3 to feb 20",
+			r:         RangeFromTimes(date(2022, 1, 3), date(2022, 2, 20)),
+			step:      Day,
+			wantN:     48,
+			wantFirst: Range{date(2022, 1, 3), 24 * time.Hour, Day},
+			wantLast:  Range{date(2022, 2, 19), 24 * time.Hour, Day},
+		},
+		{
+			name:      "weeks of jan 3 to feb 20",
+			r:         RangeFromTimes(date(2022, 1, 3), date(2022, 2, 20)),
+			step:      Week,
+			wantN:     7,
+			wantFirst: Range{date(2022, 1, 3), 6 * 24 * time.Hour, Week},
+			wantLast:  Range{date(2022, 2, 13), 7 * 24 * time.Hour, Week},
+		},
+		{
+			name:      "months of jan 3 to feb 20",
+			r:         RangeFromTimes(date(2022, 1, 3), date(2022, 2, 20)),
+			step:      Month,
+			wantN:     2,
+			wantFirst: Range{date(2022, 1, 3), 29 * 24 * time.Hour, Month},
+			wantLast:  Range{date(2022, 2, 1), 19 * 24 * time.Hour, Month},
+		},
+		{
+			name:      "months of a leap year",
+			r:         truncateYear(date(2024, 1, 1)),
+			step:      Month,
+			wantN:     12,
+			wantFirst: Range{date(2024, 1, 1), 31 * 24 * time.Hour, Month},
+			wantLast:  Range{date(2024, 12, 1), 31 * 24 * time.Hour, Month},
+		},
+		{
+			name:      "days of a month with the start of daylight saving time",
+			r:         truncateMonth(time.Date(2022, 3, 1, 0, 0, 0, 0, ny)),
+			step:      Day,
+			wantN:     31,
+			wantFirst: Range{time.Date(2022, 3, 1, 0, 0, 0, 0, ny), 24 * time.Hour, Day},
+			wantLast:  Range{time.Date(2022, 3, 31, 0, 0, 0, 0, ny), 24 * time.Hour, Day},
+		},
+		{
+			name:      "hours of the day daylight saving time ends",
+			r:         truncateDay(time.Date(2022, 11, 6, 0, 0, 0, 0, ny)),
+			step:      Hour,
+			wantN:     25,
+			wantFirst: Range{time.Date(2022, 11, 6, 0, 0, 0, 0, ny), time.Hour, Hour},
+			wantLast:  Range{time.Date(2022, 11, 6, 23, 0, 0, 0, ny), time.Hour, Hour},
+		},
+		{
+			name:      "minutes from within the repeated hour",
+			r:         RangeFromTimes(estHalfPastOne, estHalfPastOne.Add(90*time.Minute)),
+			step:      Minute,
+			wantN:     90,
+			wantFirst: Range{estHalfPastOne, time.Minute, Minute},
+			wantLast:  Range{estHalfPastOne.Add(89 * time.Minute), time.Minute, Minute},
+		},
+		{
+			name:      "hours from within the repeated hour",
+			r:         RangeFromTimes(estHalfPastOne, estHalfPastOne.Add(90*time.Minute)),
+			step:      Hour,
+			wantN:     2,
+			wantFirst: Range{estHalfPastOne, 30 * time.Minute, Hour},
+			wantLast:  Range{estHalfPastOne.Add(30 * time.Minute), time.Hour, Hour},
+		},
+		{
+			name:      "minutes",
+			r:         RangeFromTimes(date(2022, 1, 1).Add(30*time.Second), date(2022, 1, 1).Add(3*time.Minute)),
+			step:      Minute,
+			wantN:     3,
+			wantFirst: Range{date(2022, 1, 1).Add(30 * time.Second), 30 * time.Second, Minute},
+			wantLast:  Range{date(2022, 1, 1).Add(2 * time.Minute), time.Minute, Minute},
+		},
+		{
+			name:  "empty",
+			r:     RangeFromTimes(date(2022, 1, 3), date(2022, 1, 3)),
+			step:  Day,
+			wantN: 0,
+		},
+		{
+			name:  "negative",
+			r:     RangeFromTimes(date(2022, 1, 3), date(2022, 1, 1)),
+			step:  Day,
+			wantN: 0,
+		},
+		{
+			name:      "quarters",
+			r:         RangeFromTimes(date(2022, 2, 15), date(2022, 12, 1)),
+			step:      Quarter,
+			wantN:     4,
+			wantFirst: Range{date(2022, 2, 15), date(2022, 4, 1).Sub(date(2022, 2, 15)), Quarter},
+			wantLast:  Range{date(2022, 10, 1), date(2022, 12, 1).Sub(date(2022, 10, 1)), Quarter},
+		},
+		{
+			name:  "no calendar unit",
+			r:     truncateYear(date(2022, 1, 1)),
+			step:  Granularity(-1),
+			wantN: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.r.Split(tt.step)
+			if len(got) != tt.wantN {
+				t.Fatalf("len(Split()) = %d, want %d", len(got), tt.wantN)
+			}
+			if tt.wantN == 0 {
+				return
+			}
+			if first := got[0]; !first.Equal(tt.wantFirst) || first.Granularity != tt.step {
+				t.Errorf("first piece = %v, want %v", first, tt.wantFirst)
+			}
+			if last := got[len(got)-1]; !last.Equal(tt.wantLast) || last.Granularity != tt.step {
+				t.Errorf("last piece = %v, want %v", last, tt.wantLast)
+			}
+			// The pieces must cover r exactly, without gaps or overlaps.
+			var total time.Duration
+			for i, p := range got {
+				total += p.Duration
+				if p.Duration <= 0 {
+					t.Errorf("piece %d = %v, want a positive duration", i, p)
+				}
+				if i > 0 && !got[i-1].End().Equal(p.Start()) {
+					t.Errorf("piece %d starts at %v, want %v", i, p.Start(), got[i-1].End())
+				}
+			}
+			if total != tt.r.Duration {
+				t.Errorf("pieces last %v in total, want %v", total, tt.r.Duration)
+			}
+		})
+	}
+}
+
+func TestRange_DaysWeeksMonths(t *testing.T) {
+	r := RangeFromTimes(time.Date(2022, 1, 3, 0, 0, 0, 0, time.UTC), time.Date(2022, 2, 20, 0, 0, 0, 0, time.UTC))
+	if got := len(r.Days()); got != 48 {
+		t.Errorf("len(Days()) = %d, want 48", got)
+	}
+	if got := len(r.Weeks()); got != 7 {
+		t.Errorf("len(Weeks()) = %d, want 7", got)
+	}
+	if got := len(r.Months()); got != 2 {
+		t.Errorf("len(Months()) = %d, want 2", got)
+	}
+
+	// Weeks start on Sunday unless WeekStartsOn says otherwise.
+	for _, tt := range []struct {
+		options   []func(o *opts)
+		wantFirst time.Duration
+	}{
+		{nil, 6 * 24 * time.Hour},
+		{[]func(o *opts){WeekStartsOn(time.Monday)}, 7 * 24 * time.Hour},
+	} {
+		weeks := r.Weeks(tt.options...)
+		if len(weeks) != 7 || weeks[0].Duration != tt.wantFirst {
+			t.Errorf("Weeks(%d options) = %v, want 7 weeks, the first lasting %v", len(tt.options), weeks, tt.wantFirst)
+		}
+	}
+	if got := r.Split(Week, WeekStartsOn(time.Monday)); len(got) != 7 || !got[1].Start().Equal(time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Split(Week) with weeks starting on Monday = %v", got)
+	}
+}
@@ -0,0 +1,128 @@
+package anytime
+
+import (
+	"sort"
+	"time"
+)
+
+// RangeSet is a set of times made up of disjoint ranges, such as the result
+// of parsing "weekdays in march". Its ranges are kept sorted, with
+// overlapping and touching ranges merged and empty ranges dropped.
+type RangeSet struct {
+	ranges []Range
+}
+
+// NewRangeSet returns the set of times covered by any of the given ranges.
+func NewRangeSet(ranges ...Range) RangeSet {
+	var rs []Range
+	for _, r := range ranges {
+		if !r.empty() {
+			rs = append(rs, r)
+		}
+	}
+	sort.SliceStable(rs, func(i, j int) bool {
+		return rs[i].start.Before(rs[j].start)
+	})
+	var merged []Range
+	for _, r := range rs {
+		if n := len(merged); n > 0 {
+			if u, ok := merged[n-1].Union(r); ok {
+				merged[n-1] = u
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+	return RangeSet{merged}
+}
+
+// Ranges returns the disjoint ranges making up s, in order.
+func (s RangeSet) Ranges() []Range {
+	return append([]Range(nil), s.ranges...)
+}
+
+// Len returns the number of disjoint ranges making up s.
+func (s RangeSet) Len() int {
+	return len(s.ranges)
+}
+
+// Duration returns the total duration of the ranges making up s.
+func (s RangeSet) Duration() time.Duration {
+	var d time.Duration
+	for _, r := range s.ranges {
+		d += r.Duration
+	}
+	return d
+}
+
+// Contains reports whether t is within any of the ranges making up s.
+func (s RangeSet) Contains(t time.Time) bool {
+	for _, r := range s.ranges {
+		if r.Contains(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Equal returns true if the two sets cover the same times.
+func (s RangeSet) Equal(other RangeSet) bool {
+	if len(s.ranges) != len(other.ranges) {
+		return false
+	}
+	for i, r := range s.ranges {
+		if !r.Equal(other.ranges[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Union returns the set of times in either s or other.
+func (s RangeSet) Union(other RangeSet) RangeSet {
+	return NewRangeSet(append(s.Ranges(), other.ranges...)...)
+}
+
+// Intersect returns the set of times in both s and other.
+func (s RangeSet) Intersect(other RangeSet) RangeSet {
+	var rs []Range
+	i, j := 0, 0
+	for i < len(s.ranges) && j < len(other.ranges) {
+		a, b := s.ranges[i], other.ranges[j]
+		if r, ok := a.Intersect(b); ok {
+			rs = append(rs, r)
+		}
+		// Move past whichever range ends first, since it cannot overlap
+		// anything further along in the other set.
+		if a.End().Before(b.End()) {
+			i++
+		} else {
+			j++
+		}
+	}
+	return RangeSet{rs}
+}
+
+// Subtract returns the set of times in s but not in other.
+func (s RangeSet) Subtract(other RangeSet) RangeSet {
+	var rs []Range
+	for _, a := range s.ranges {
+		start, end := a.start, a.End()
+		for _, b := range other.ranges {
+			if !b.End().After(start) {
+				continue
+			}
+			if !b.start.Before(end) {
+				break
+			}
+			if b.start.After(start) {
+				rs = append(rs, Range{start, b.start.Sub(start), a.Granularity})
+			}
+			start = b.End()
+		}
+		if end.After(start) {
+			rs = append(rs, Range{start, end.Sub(start), a.Granularity})
+		}
+	}
+	return RangeSet{rs}
+}
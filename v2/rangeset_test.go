@@ -0,0 +1,103 @@
+package anytime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewRangeSet(t *testing.T) {
+	tests := []struct {
+		name   string
+		ranges []Range
+		want   []Range
+	}{
+		{"nothing", nil, nil},
+		{"one", []Range{hours(1, 2)}, []Range{hours(1, 2)}},
+		{"sorted", []Range{hours(5, 6), hours(1, 2)}, []Range{hours(1, 2), hours(5, 6)}},
+		{"overlapping merged", []Range{hours(1, 3), hours(2, 4)}, []Range{hours(1, 4)}},
+		{"touching merged", []Range{hours(3, 4), hours(1, 3)}, []Range{hours(1, 4)}},
+		{"nested merged", []Range{hours(1, 6), hours(2, 3), hours(4, 5)}, []Range{hours(1, 6)}},
+		{"chain merged", []Range{hours(1, 2), hours(5, 6), hours(2, 5)}, []Range{hours(1, 6)}},
+		{"empty dropped", []Range{hours(1, 1), hours(3, 2), hours(4, 5)}, []Range{hours(4, 5)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewRangeSet(tt.ranges...).Ranges()
+			if len(got) != len(tt.want) {
+				t.Fatalf("Ranges() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("Ranges()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRangeSet_ops(t *testing.T) {
+	a := NewRangeSet(hours(1, 3), hours(5, 8), hours(10, 12))
+	b := NewRangeSet(hours(2, 6), hours(7, 11), hours(13, 14))
+	tests := []struct {
+		name string
+		got  RangeSet
+		want RangeSet
+	}{
+		{"union", a.Union(b), NewRangeSet(hours(1, 12), hours(13, 14))},
+		{"intersect", a.Intersect(b), NewRangeSet(hours(2, 3), hours(5, 6), hours(7, 8), hours(10, 11))},
+		{"a minus b", a.Subtract(b), NewRangeSet(hours(1, 2), hours(6, 7), hours(11, 12))},
+		{"b minus a", b.Subtract(a), NewRangeSet(hours(3, 5), hours(8, 10), hours(13, 14))},
+		{"union with nothing", a.Union(RangeSet{}), a},
+		{"intersect with nothing", a.Intersect(RangeSet{}), RangeSet{}},
+		{"minus nothing", a.Subtract(RangeSet{}), a},
+		{"minus itself", a.Subtract(a), RangeSet{}},
+		{"minus covering", a.Subtract(NewRangeSet(hours(0, 20))), RangeSet{}},
+		{"minus inside", NewRangeSet(hours(1, 10)).Subtract(NewRangeSet(hours(2, 3), hours(4, 5))), NewRangeSet(hours(1, 2), hours(3, 4), hours(5, 10))},
+		{"intersect touching", NewRangeSet(hours(1, 2)).Intersect(NewRangeSet(hours(2, 3))), RangeSet{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", tt.got.Ranges(), tt.want.Ranges())
+			}
+		})
+	}
+}
+
+func TestRangeSet_Duration(t *testing.T) {
+	s := NewRangeSet(hours(1, 3), hours(2, 4), hours(6, 7))
+	if got, want := s.Duration(), 4*time.Hour; got != want {
+		t.Errorf("Duration() = %v, want %v", got, want)
+	}
+	if got := (RangeSet{}).Duration(); got != 0 {
+		t.Errorf("Duration() of empty set = %v, want 0", got)
+	}
+}
+
+func TestRangeSet_Contains(t *testing.T) {
+	s := NewRangeSet(hours(1, 3), hours(5, 6))
+	tests := []struct {
+		t    time.Time
+		want bool
+	}{
+		{at(0), false},
+		{at(1), true},
+		{at(2), true},
+		{at(3), false},
+		{at(5), true},
+		{at(6), false},
+	}
+	for _, tt := range tests {
+		if got := s.Contains(tt.t); got != tt.want {
+			t.Errorf("Contains(%v) = %v, want %v", tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestRangeSet_Ranges_copies(t *testing.T) {
+	s := NewRangeSet(hours(1, 2))
+	s.Ranges()[0] = hours(5, 6)
+	if !s.Equal(NewRangeSet(hours(1, 2))) {
+		t.Errorf("modifying the result of Ranges() changed the set to %v", s.Ranges())
+	}
+}
@@ -0,0 +1,446 @@
+package anytime
+
+import (
+	"errors"
+	"time"
+)
+
+var ErrNoRecurrenceFound = errors.New("no recurrence found")
+
+// Frequency is the calendar unit by which a Recurrence repeats.
+type Frequency int
+
+// Frequencies, from most to least frequent.
+const (
+	Daily Frequency = iota
+	Weekly
+	Monthly
+	Yearly
+)
+
+// String returns the name of the frequency.
+func (f Frequency) String() string {
+	switch f {
+	case Daily:
+		return "daily"
+	case Weekly:
+		return "weekly"
+	case Monthly:
+		return "monthly"
+	case Yearly:
+		return "yearly"
+	}
+	return "unknown"
+}
+
+// Recurrence is a rule for an event that repeats, such as
+// "every monday at 9am" or "every first of the month".
+type Recurrence struct {
+	// start is the beginning of the day from which intervals are counted.
+	// There are no occurrences before it.
+	start time.Time
+
+	frequency Frequency
+
+	// interval is the number of days, weeks, months or years between
+	// occurrences, as in "every other week".
+	interval int
+
+	// weekdays are the days of the week on which a weekly recurrence occurs,
+	// indexed by time.Weekday. For a monthly recurrence with nth set, they
+	// are the days of the week it picks from.
+	weekdays [7]bool
+
+	// nth picks a day within the month for a monthly recurrence from those
+	// on the weekdays, as in "every 2nd tuesday" or "every first weekday of
+	// the month". It counts from 1, or is -1 for the last such day of the
+	// month. It is 0 if not used.
+	nth int
+
+	// monthDay is the day of the month on which a monthly or yearly
+	// recurrence occurs, or -1 for the last day of the month.
+	monthDay int
+
+	// month is the month in which a yearly recurrence occurs.
+	month time.Month
+
+	// clock is the time of day of each occurrence, or nil if occurrences
+	// last the whole day.
+	clock *clock
+
+	// weekStart is the day on which the weeks counted by a weekly
+	// recurrence with an interval start.
+	weekStart time.Weekday
+}
+
+// ParseRecurrence parses a recurring expression at the beginning of s, such
+// as "every monday at 9am", "every other week", "every other week on monday",
+// "every weekday at 8:30", "every 2nd tuesday", "every last friday of the
+// month", "every first of the month" or "every march 3". An ordinal before
+// days of the week picks one of those days in each month, whether or not it
+// is followed by "of the month", so "every 2nd tuesday" is the second Tuesday
+// of each month and "every first weekday of the month" is the first business
+// day of each month. "every other tuesday" is every second week.
+//
+// Occurrences are counted from the day of now, so "every other week" falls on
+// the weekday of now, and happen no earlier than that day. Weeks start on the
+// day given by the WeekStartsOn option, or on Sunday. The prefix of
+// s that was parsed is also returned.
+func ParseRecurrence(s string, now time.Time, options ...func(o *opts)) (rec Recurrence, parsed string, err error) {
+	o := newOpts(options...)
+	sofw, eofw, fw := findSignalNoise(s, 0)
+	if fw != "every" && fw != "each" {
+		return Recurrence{}, "", ErrNoRecurrenceFound
+	}
+	rec = Recurrence{start: truncateDay(now).start, interval: 1, weekStart: o.weekStart}
+
+	// An ordinal or count just after "every", as in "every 2nd tuesday" or
+	// "every 3 days".
+	pos := eofw
+	_, eow, w := findSignalNoise(s, pos)
+	ord, count, other := 0, 0, false
+	if w == "other" {
+		ord, other = 2, true
+	} else if w == "last" {
+		ord = -1
+	} else if i, ok := parseOrdinal(w); ok {
+		ord = i
+	} else if i, ok := parseInt(w); ok && i > 0 && w != "a" && w != "an" {
+		count = i
+	}
+	if ord != 0 || count != 0 {
+		pos = eow
+	}
+
+	_, eow, w = findSignalNoise(s, pos)
+	days, n, _, eowd := parseWeekdays(s, pos)
+	switch {
+	case n > 0:
+		// "every monday", "every weekday", "every other tuesday", "every 2nd
+		// tuesday of the month"
+		if ord != 0 && !other {
+			if ord > 5 {
+				// No month has a 6th monday.
+				return Recurrence{}, "", ErrNoRecurrenceFound
+			}
+			rec.frequency = Monthly
+			rec.weekdays = days
+			rec.nth = ord
+			pos = eowd
+			if m, eoof, ok := parseOfMonth(s, eowd); ok && m == 0 {
+				pos = eoof
+			}
+			break
+		}
+		rec.frequency = Weekly
+		rec.weekdays = days
+		pos = eowd
+
+	case w == "day" && ord != 0:
+		// "every first day of the month", "every last day of the month"
+		m, eoof, ok := parseOfMonth(s, eow)
+		if !ok || m != 0 {
+			if ord < 0 {
+				return Recurrence{}, "", ErrNoRecurrenceFound
+			}
+			rec.frequency = Daily
+			pos = eow
+			break
+		}
+		if !okDayOfMonth(ord) && ord != -1 {
+			return Recurrence{}, "", ErrNoRecurrenceFound
+		}
+		rec.frequency = Monthly
+		rec.monthDay = ord
+		pos = eoof
+
+	case isFrequencyUnit(w):
+		// "every day", "every other week", "every 3 months"
+		if ord < 0 {
+			return Recurrence{}, "", ErrNoRecurrenceFound
+		}
+		rec.frequency = unitNameToFrequency[w]
+		pos = eow
+
+		// "every other week on monday and thursday"
+		if _, eoon, on := findSignalNoise(s, eow); rec.frequency == Weekly && on == "on" {
+			if days, n, _, eowd := parseWeekdays(s, eoon); n > 0 {
+				rec.weekdays = days
+				pos = eowd
+			}
+		}
+
+	case ord != 0 && w == "of":
+		// "every first of the month", "every 3rd of march"
+		m, eoof, ok := parseOfMonth(s, pos)
+		if !ok || (!okDayOfMonth(ord) && ord != -1) {
+			return Recurrence{}, "", ErrNoRecurrenceFound
+		}
+		rec.frequency = Monthly
+		if m != 0 {
+			if ord < 0 {
+				return Recurrence{}, "", ErrNoRecurrenceFound
+			}
+			rec.frequency = Yearly
+			rec.month = m
+		}
+		rec.monthDay = ord
+		pos = eoof
+
+	case ord == 0 && count == 0 && monthNameToMonth[w] != 0:
+		// "every march 3"
+		_, eod, dw := findSignalNoise(s, eow)
+		dom, ok := parseDayOfMonth(dw)
+		if !ok {
+			return Recurrence{}, "", ErrNoRecurrenceFound
+		}
+		rec.frequency = Yearly
+		rec.month = monthNameToMonth[w]
+		rec.monthDay = dom
+		pos = eod
+
+	default:
+		return Recurrence{}, "", ErrNoRecurrenceFound
+	}
+	if rec.nth == 0 && rec.monthDay == 0 {
+		// The ordinal or count was the number of units between occurrences.
+		if ord+count > 0 {
+			rec.interval = ord + count
+		}
+	}
+	rec.fillDefaults()
+
+	// A time of day, as in "every monday at 9am".
+	_, eoat, at := findSignalNoise(s, pos)
+	if !eq(at, "at") {
+		eoat = pos
+	}
+	c, eoc, err := parseClock(s, findNextSignal(s, eoat), o.zonePolicy)
+	if err == ErrAmbiguousZone {
+		return Recurrence{}, "", err
+	}
+	if err == nil {
+		rec.clock = &c
+		pos = eoc
+	}
+	return rec, s[sofw:pos], nil
+}
+
+// parseOfMonth parses a phrase such as "of the month", "of every month" or
+// "of march" starting at start in s. It returns the month named, or 0 if the
+// phrase refers to every month, and the end of the phrase.
+func parseOfMonth(s string, start int) (m time.Month, end int, ok bool) {
+	_, eoof, of := findSignalNoise(s, start)
+	if of != "of" {
+		return 0, 0, false
+	}
+	_, eow, w := findSignalNoise(s, eoof)
+	if m, ok := monthNameToMonth[w]; ok {
+		return m, eow, true
+	}
+	if w == "the" || w == "every" || w == "each" {
+		_, eow, w = findSignalNoise(s, eow)
+	}
+	if w != "month" {
+		return 0, 0, false
+	}
+	return 0, eow, true
+}
+
+func isFrequencyUnit(w string) bool {
+	_, ok := unitNameToFrequency[w]
+	return ok
+}
+
+var unitNameToFrequency = map[string]Frequency{
+	"day":    Daily,
+	"days":   Daily,
+	"week":   Weekly,
+	"weeks":  Weekly,
+	"month":  Monthly,
+	"months": Monthly,
+	"year":   Yearly,
+	"years":  Yearly,
+}
+
+// fillDefaults takes anything left unsaid in rec from the day it starts, so
+// that "every week" falls on the weekday of the start and "every month" on
+// its day of the month.
+func (rec *Recurrence) fillDefaults() {
+	if rec.frequency != Weekly || rec.interval == 1 {
+		// Only weekly recurrences with an interval count weeks.
+		rec.weekStart = time.Sunday
+	}
+	switch rec.frequency {
+	case Weekly:
+		if rec.weekdays == [7]bool{} {
+			rec.weekdays[rec.start.Weekday()] = true
+		}
+	case Monthly:
+		if rec.nth == 0 && rec.monthDay == 0 {
+			rec.monthDay = rec.start.Day()
+		}
+	case Yearly:
+		if rec.month == 0 {
+			rec.month = rec.start.Month()
+			rec.monthDay = rec.start.Day()
+		}
+	}
+}
+
+// Start returns when the first day on which rec may occur begins, or if rec
+// has a time of day, that time on that day, in the time zone given with it.
+func (rec Recurrence) Start() time.Time {
+	return rec.occurrenceOn(truncateDay(rec.start)).start
+}
+
+// maxPeriods is how many days, weeks, months or years Next looks through
+// for an occurrence. Every recurrence that can happen at all does so within
+// the first eight years, which is long enough to find a 29th of February.
+const maxPeriods = 100
+
+// Next returns the first occurrence of rec that starts after the given time.
+// It returns false if there is none within the next hundred intervals, as for
+// "every 30th of february".
+func (rec Recurrence) Next(after time.Time) (Range, bool) {
+	from := truncateDay(later(after, rec.start))
+	u := rec.unit()
+
+	// Go straight to the first period that is a whole number of intervals
+	// from the start, then only look at the days of such periods.
+	p := u.truncate(from.start)
+	if n := rec.periodsFromStart(p.start) % rec.interval; n != 0 {
+		p = u.truncate(u.add(p.start, rec.interval-n))
+	}
+	for i := 0; i < maxPeriods; i++ {
+		for d := truncateDay(later(p.start, from.start)); d.start.Before(p.End()); d = dayUnit.following(d) {
+			if !rec.on(d.start) {
+				continue
+			}
+			if o := rec.occurrenceOn(d); o.start.After(after) {
+				return o, true
+			}
+		}
+		p = u.truncate(u.add(p.start, rec.interval))
+	}
+	return Range{}, false
+}
+
+// Between returns the occurrences of rec that start within r, in order.
+func (rec Recurrence) Between(r Range) []Range {
+	var rs []Range
+	rec.between(r, func(o Range) bool {
+		rs = append(rs, o)
+		return true
+	})
+	return rs
+}
+
+// between calls yield with each of the occurrences Between would return,
+// stopping early if yield returns false.
+func (rec Recurrence) between(r Range, yield func(Range) bool) {
+	if r.empty() {
+		return
+	}
+	t := r.start.Add(-time.Nanosecond)
+	for {
+		o, ok := rec.Next(t)
+		if !ok || !o.start.Before(r.End()) || !yield(o) {
+			return
+		}
+		t = o.start
+	}
+}
+
+// occurrenceOn returns the occurrence of rec on the day d.
+func (rec Recurrence) occurrenceOn(d Range) Range {
+	if rec.clock == nil {
+		return d
+	}
+	return rec.clock.on(d)
+}
+
+// on reports whether rec occurs on the day starting at d.
+func (rec Recurrence) on(d time.Time) bool {
+	if d.Before(rec.start) || rec.periodsFromStart(d)%rec.interval != 0 {
+		return false
+	}
+	switch rec.frequency {
+	case Daily:
+		return true
+	case Weekly:
+		return rec.weekdays[d.Weekday()]
+	case Monthly:
+		return rec.onDayOfMonth(d)
+	case Yearly:
+		return d.Month() == rec.month && rec.onDayOfMonth(d)
+	}
+	return false
+}
+
+// periodsFromStart returns the number of days, weeks, months or years,
+// according to the frequency of rec, from the one containing its start to
+// the one containing t.
+func (rec Recurrence) periodsFromStart(t time.Time) int {
+	y, m, _ := t.Date()
+	sy, sm, _ := rec.start.Date()
+	switch rec.frequency {
+	case Weekly:
+		return (civilDay(truncateWeekFrom(t, rec.weekStart).start) - civilDay(truncateWeekFrom(rec.start, rec.weekStart).start)) / 7
+	case Monthly:
+		return (y-sy)*12 + int(m-sm)
+	case Yearly:
+		return y - sy
+	}
+	return civilDay(t) - civilDay(rec.start)
+}
+
+// unit returns the unit of time by which rec repeats.
+func (rec Recurrence) unit() unit {
+	switch rec.frequency {
+	case Weekly:
+		return weekUnitFrom(rec.weekStart)
+	case Monthly:
+		return monthUnit
+	case Yearly:
+		return yearUnit
+	}
+	return dayUnit
+}
+
+// onDayOfMonth reports whether the day d is the day of the month picked by
+// rec's monthDay, or by nth from the days of the month on its weekdays.
+func (rec Recurrence) onDayOfMonth(d time.Time) bool {
+	y, m, dom := d.Date()
+	last := time.Date(y, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+	if rec.nth != 0 {
+		if !rec.weekdays[d.Weekday()] {
+			return false
+		}
+		// Count the days on the weekdays up to d, or from d to the end of
+		// the month if counting from the end.
+		from, to, want := 1, dom, rec.nth
+		if rec.nth < 0 {
+			from, to, want = dom, last, -rec.nth
+		}
+		n := 0
+		for i := from; i <= to; i++ {
+			if rec.weekdays[time.Date(y, m, i, 0, 0, 0, 0, time.UTC).Weekday()] {
+				n++
+			}
+		}
+		return n == want
+	}
+	if rec.monthDay < 0 {
+		return dom == last
+	}
+	return dom == rec.monthDay
+}
+
+// civilDay returns the number of days from the Unix epoch to the date of t,
+// ignoring the time of day and zone, so that days can be counted across
+// changes to daylight saving time.
+func civilDay(t time.Time) int {
+	y, m, d := t.Date()
+	return int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / (24 * 60 * 60))
+}
@@ -0,0 +1,267 @@
+package anytime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRecurrence(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	date := func(y int, m time.Month, d, h, min int) time.Time {
+		return time.Date(y, m, d, h, min, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		input      string
+		wantParsed string
+		// wantNext are the starts of the first few occurrences after now.
+		wantNext []time.Time
+		wantDur  time.Duration
+	}{
+		{
+			input:      "every monday at 9am to catch up",
+			wantParsed: "every monday at 9am",
+			wantNext:   []time.Time{date(2022, 10, 3, 9, 0), date(2022, 10, 10, 9, 0), date(2022, 10, 17, 9, 0)},
+			wantDur:    time.Hour,
+		},
+		{
+			input:      "every other week",
+			wantParsed: "every other week",
+			wantNext:   []time.Time{date(2022, 10, 13, 0, 0), date(2022, 10, 27, 0, 0), date(2022, 11, 10, 0, 0)},
+			wantDur:    24 * time.Hour,
+		},
+		{
+			input:      "every weekday at 8:30",
+			wantParsed: "every weekday at 8:30",
+			wantNext:   []time.Time{date(2022, 9, 29, 8, 30), date(2022, 9, 30, 8, 30), date(2022, 10, 3, 8, 30)},
+			wantDur:    time.Minute,
+		},
+		{
+			input:      "every 2nd tuesday",
+			wantParsed: "every 2nd tuesday",
+			wantNext:   []time.Time{date(2022, 10, 11, 0, 0), date(2022, 11, 8, 0, 0), date(2022, 12, 13, 0, 0)},
+			wantDur:    24 * time.Hour,
+		},
+		{
+			input:      "every other tuesday",
+			wantParsed: "every other tuesday",
+			wantNext:   []time.Time{date(2022, 10, 11, 0, 0), date(2022, 10, 25, 0, 0), date(2022, 11, 8, 0, 0)},
+			wantDur:    24 * time.Hour,
+		},
+		{
+			input:      "every last monday",
+			wantParsed: "every last monday",
+			wantNext:   []time.Time{date(2022, 10, 31, 0, 0), date(2022, 11, 28, 0, 0), date(2022, 12, 26, 0, 0)},
+			wantDur:    24 * time.Hour,
+		},
+		{
+			input:      "every first weekday of the month",
+			wantParsed: "every first weekday of the month",
+			wantNext:   []time.Time{date(2022, 10, 3, 0, 0), date(2022, 11, 1, 0, 0), date(2022, 12, 1, 0, 0)},
+			wantDur:    24 * time.Hour,
+		},
+		{
+			input:      "every last weekday of the month",
+			wantParsed: "every last weekday of the month",
+			wantNext:   []time.Time{date(2022, 9, 30, 0, 0), date(2022, 10, 31, 0, 0), date(2022, 11, 30, 0, 0)},
+			wantDur:    24 * time.Hour,
+		},
+		{
+			input:      "every 2nd tuesday of the month",
+			wantParsed: "every 2nd tuesday of the month",
+			wantNext:   []time.Time{date(2022, 10, 11, 0, 0), date(2022, 11, 8, 0, 0), date(2022, 12, 13, 0, 0)},
+			wantDur:    24 * time.Hour,
+		},
+		{
+			input:      "every last friday of the month",
+			wantParsed: "every last friday of the month",
+			wantNext:   []time.Time{date(2022, 9, 30, 0, 0), date(2022, 10, 28, 0, 0), date(2022, 11, 25, 0, 0)},
+			wantDur:    24 * time.Hour,
+		},
+		{
+			input:      "every first of the month",
+			wantParsed: "every first of the month",
+			wantNext:   []time.Time{date(2022, 10, 1, 0, 0), date(2022, 11, 1, 0, 0), date(2022, 12, 1, 0, 0)},
+			wantDur:    24 * time.Hour,
+		},
+		{
+			input:      "every 31st of every month",
+			wantParsed: "every 31st of every month",
+			wantNext:   []time.Time{date(2022, 10, 31, 0, 0), date(2022, 12, 31, 0, 0), date(2023, 1, 31, 0, 0)},
+			wantDur:    24 * time.Hour,
+		},
+		{
+			input:      "every last day of the month at 5pm",
+			wantParsed: "every last day of the month at 5pm",
+			wantNext:   []time.Time{date(2022, 9, 30, 17, 0), date(2022, 10, 31, 17, 0), date(2022, 11, 30, 17, 0)},
+			wantDur:    time.Hour,
+		},
+		{
+			input:      "every 3 days",
+			wantParsed: "every 3 days",
+			wantNext:   []time.Time{date(2022, 10, 2, 0, 0), date(2022, 10, 5, 0, 0), date(2022, 10, 8, 0, 0)},
+			wantDur:    24 * time.Hour,
+		},
+		{
+			input:      "every month",
+			wantParsed: "every month",
+			wantNext:   []time.Time{date(2022, 10, 29, 0, 0), date(2022, 11, 29, 0, 0), date(2022, 12, 29, 0, 0)},
+			wantDur:    24 * time.Hour,
+		},
+		{
+			input:      "every year",
+			wantParsed: "every year",
+			wantNext:   []time.Time{date(2023, 9, 29, 0, 0), date(2024, 9, 29, 0, 0), date(2025, 9, 29, 0, 0)},
+			wantDur:    24 * time.Hour,
+		},
+		{
+			input:      "every march 3",
+			wantParsed: "every march 3",
+			wantNext:   []time.Time{date(2023, 3, 3, 0, 0), date(2024, 3, 3, 0, 0), date(2025, 3, 3, 0, 0)},
+			wantDur:    24 * time.Hour,
+		},
+		{
+			input:      "every 3rd of march",
+			wantParsed: "every 3rd of march",
+			wantNext:   []time.Time{date(2023, 3, 3, 0, 0), date(2024, 3, 3, 0, 0), date(2025, 3, 3, 0, 0)},
+			wantDur:    24 * time.Hour,
+		},
+		{
+			input:      "Each day at noon",
+			wantParsed: "Each day at noon",
+			wantNext:   []time.Time{date(2022, 9, 29, 12, 0), date(2022, 9, 30, 12, 0), date(2022, 10, 1, 12, 0)},
+			wantDur:    time.Hour,
+		},
+		{
+			input:      "every mon and wed 10am",
+			wantParsed: "every mon and wed 10am",
+			wantNext:   []time.Time{date(2022, 10, 3, 10, 0), date(2022, 10, 5, 10, 0), date(2022, 10, 10, 10, 0)},
+			wantDur:    time.Hour,
+		},
+		{
+			input:      "every other week on monday at 9am",
+			wantParsed: "every other week on monday at 9am",
+			wantNext:   []time.Time{date(2022, 10, 10, 9, 0), date(2022, 10, 24, 9, 0), date(2022, 11, 7, 9, 0)},
+			wantDur:    time.Hour,
+		},
+		{
+			input:      "every week on tue and fri",
+			wantParsed: "every week on tue and fri",
+			wantNext:   []time.Time{date(2022, 9, 30, 0, 0), date(2022, 10, 4, 0, 0), date(2022, 10, 7, 0, 0)},
+			wantDur:    24 * time.Hour,
+		},
+		{
+			input:      "every 30th of february",
+			wantParsed: "every 30th of february",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			rec, parsed, err := ParseRecurrence(tt.input, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if parsed != tt.wantParsed {
+				t.Errorf("parsed = %q, want %q", parsed, tt.wantParsed)
+			}
+			after := now
+			for i, want := range tt.wantNext {
+				got, ok := rec.Next(after)
+				if !ok {
+					t.Fatalf("Next() #%d found nothing", i)
+				}
+				if !got.Start().Equal(want) || got.Duration != tt.wantDur {
+					t.Errorf("Next() #%d = %v lasting %v, want %v lasting %v", i, got.Start(), got.Duration, want, tt.wantDur)
+				}
+				after = got.Start()
+			}
+			if len(tt.wantNext) == 0 {
+				if got, ok := rec.Next(after); ok {
+					t.Errorf("Next() = %v, want nothing", got)
+				}
+			}
+		})
+	}
+}
+
+func TestParseRecurrence_fail(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"monday",
+		"every",
+		"every foo",
+		"every last week",
+		"every 6th monday",
+		"every 40th of the month",
+		"every 6th monday of the month",
+		"every march",
+	} {
+		t.Run(s, func(t *testing.T) {
+			if _, _, err := ParseRecurrence(s, time.Time{}); err == nil {
+				t.Error("parsing succeeded, want failure")
+			}
+		})
+	}
+}
+
+func TestRecurrence_Between(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	rec, _, err := ParseRecurrence("every weekday at 9am", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	week := truncateWeek(now.AddDate(0, 0, 7))
+	got := rec.Between(week)
+	if len(got) != 5 {
+		t.Fatalf("Between() returned %d occurrences, want 5: %v", len(got), got)
+	}
+	for i, o := range got {
+		want := time.Date(2022, 10, 3+i, 9, 0, 0, 0, time.UTC)
+		if !o.Start().Equal(want) {
+			t.Errorf("occurrence %d starts at %v, want %v", i, o.Start(), want)
+		}
+	}
+	if got := rec.Between(RangeFromTimes(now, now)); got != nil {
+		t.Errorf("Between() on an empty range = %v, want nil", got)
+	}
+}
+
+func TestRecurrence_NextAcrossDST(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+	now := time.Date(2022, 11, 4, 12, 0, 0, 0, ny)
+	rec, _, err := ParseRecurrence("every day at 9am", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := now
+	for _, day := range []int{5, 6, 7} {
+		o, ok := rec.Next(after)
+		if !ok {
+			t.Fatal("Next() found nothing")
+		}
+		if want := time.Date(2022, 11, day, 9, 0, 0, 0, ny); !o.Start().Equal(want) {
+			t.Errorf("Next() = %v, want %v", o.Start(), want)
+		}
+		after = o.Start()
+	}
+}
+
+func TestFrequency_String(t *testing.T) {
+	tests := []struct {
+		f    Frequency
+		want string
+	}{
+		{Daily, "daily"},
+		{Weekly, "weekly"},
+		{Monthly, "monthly"},
+		{Yearly, "yearly"},
+		{Frequency(-1), "unknown"},
+	}
+	for _, tt := range tests {
+		if got := tt.f.String(); got != tt.want {
+			t.Errorf("Frequency(%d).String() = %q, want %q", tt.f, got, tt.want)
+		}
+	}
+}
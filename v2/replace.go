@@ -12,9 +12,9 @@ const (
 	Past
 )
 
-// ReplaceAllRangesByFunc replaces all dates and date ranges within the string
-// s by the result of calling f on the parsed date/range and the substring
-// src that gave rise to it.
+// ReplaceAllByFunc replaces all dates and date ranges within the string s by
+// the result of calling f on the parsed date/range and the substring src that
+// gave rise to it.
 //
 // Ranges include things like "this year". That is the range from Jan 1 to Dec
 // 31 of the current year.
@@ -23,27 +23,21 @@ const (
 // "last year to next year".
 //
 // In ambiguous cases like "December" that could be in the past or the future,
-// the dir argument tells whether to choose the Past or Future instance of it.
-func ReplaceAllRangesByFunc(s string, now time.Time, dir Direction, f func(src string, r Range) string) string {
+// the Future instance is chosen unless the DefaultToPast option is given.
+func ReplaceAllByFunc(s string, now time.Time, f func(src string, r Range) string, options ...func(o *opts)) string {
 	var parts []string
 	endOfPrevDate := 0
-	p := 0
-	for p < len(s) {
-		// sofw is the start of the first word.
-		sofw := findNextSignal(s, p)
-		r, parsed, err := ParseRange(s[sofw:], now, dir)
-		if err != nil {
-			// eofw is the end of the first word.
-			eofw := findNextNoise(s, sofw)
-			p = eofw
-			continue
-		}
-		parts = append(parts, s[endOfPrevDate:sofw])
-		fr := f(parsed, r)
-		parts = append(parts, fr)
-		p = sofw + len(parsed)
-		endOfPrevDate = p
+	for _, m := range FindAll(s, now, options...) {
+		parts = append(parts, s[endOfPrevDate:m.Start])
+		parts = append(parts, f(m.Src, m.Range))
+		endOfPrevDate = m.End
 	}
 	parts = append(parts, s[endOfPrevDate:])
 	return strings.Join(parts, "")
 }
+
+// ReplaceAllRangesByFunc is like ReplaceAllByFunc, choosing the instance of
+// ambiguous dates given by dir.
+func ReplaceAllRangesByFunc(s string, now time.Time, dir Direction, f func(src string, r Range) string) string {
+	return ReplaceAllByFunc(s, now, f, WithDirection(dir))
+}
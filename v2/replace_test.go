@@ -208,6 +208,25 @@ func TestReplaceAllRangesByFunc_ok(t *testing.T) {
 		{"31/3/2014", truncateDay(time.Date(2014, 3, 31, 0, 0, 0, 0, now.Location()))},
 		{"31-3-2014", truncateDay(time.Date(2014, 3, 31, 0, 0, 0, 0, now.Location()))},
 
+		// seconds, minutes and hours
+		{`30 seconds ago`, Range{time.Date(2022, 9, 29, 2, 48, 3, 0, time.UTC), time.Second, Second}},
+		{`a second from now`, Range{time.Date(2022, 9, 29, 2, 48, 34, 0, time.UTC), time.Second, Second}},
+		{`12 minutes ago`, Range{time.Date(2022, 9, 29, 2, 36, 0, 0, time.UTC), time.Minute, Minute}},
+		{`five minutes hence`, Range{time.Date(2022, 9, 29, 2, 53, 0, 0, time.UTC), time.Minute, Minute}},
+		{`an hour ago`, Range{time.Date(2022, 9, 29, 1, 0, 0, 0, time.UTC), time.Hour, Hour}},
+		{`3 hours from now`, Range{time.Date(2022, 9, 29, 5, 0, 0, 0, time.UTC), time.Hour, Hour}},
+
+		// in N units, within N units
+		{`in 30 seconds`, Range{time.Date(2022, 9, 29, 2, 49, 3, 0, time.UTC), time.Second, Second}},
+		{`in an hour`, Range{time.Date(2022, 9, 29, 3, 0, 0, 0, time.UTC), time.Hour, Hour}},
+		{`in 3 days`, truncateDay(now.AddDate(0, 0, 3))},
+		{`In two weeks`, truncateWeek(now.AddDate(0, 0, 14))},
+		{`in a month`, truncateMonth(now.AddDate(0, 1, 0))},
+		{`in twenty years`, truncateYear(now.AddDate(20, 0, 0))},
+		{`in 3 days at 5pm`, Range{time.Date(2022, 10, 2, 17, 0, 0, 0, time.UTC), time.Hour, Hour}},
+		{`within 3 days`, RangeFromTimes(now, now.AddDate(0, 0, 3))},
+		{`within an hour`, RangeFromTimes(now, now.Add(time.Hour))},
+
 		//// days
 		{`One day ago`, truncateDay(now.Add(-24 * time.Hour))},
 		{`1 day ago`, truncateDay(now.Add(-24 * time.Hour))},
@@ -249,9 +268,23 @@ func TestReplaceAllRangesByFunc_ok(t *testing.T) {
 		{`2008CE`, truncateYear(time.Date(2008, 1, 1, 0, 0, 0, 0, now.Location()))},
 		{`2008 CE`, truncateYear(time.Date(2008, 1, 1, 0, 0, 0, 0, now.Location()))},
 
+		// weekdays
+		{"Friday", truncateDay(time.Date(2022, 9, 30, 0, 0, 0, 0, time.UTC))},
+		{"last Monday", truncateDay(time.Date(2022, 9, 26, 0, 0, 0, 0, time.UTC))},
+		{"next Wed", truncateDay(time.Date(2022, 10, 5, 0, 0, 0, 0, time.UTC))},
+
+		// times of day
+		{"5pm", Range{time.Date(2022, 9, 29, 17, 0, 0, 0, time.UTC), time.Hour, Hour}},
+		{"noon", Range{time.Date(2022, 9, 29, 12, 0, 0, 0, time.UTC), time.Hour, Hour}},
+		{"10:30am", Range{time.Date(2022, 9, 29, 10, 30, 0, 0, time.UTC), time.Minute, Minute}},
+		{"17:45:10", Range{time.Date(2022, 9, 29, 17, 45, 10, 0, time.UTC), time.Second, Second}},
+		{"Tomorrow at 5pm", Range{time.Date(2022, 9, 30, 17, 0, 0, 0, time.UTC), time.Hour, Hour}},
+		{"5pm tomorrow", Range{time.Date(2022, 9, 30, 17, 0, 0, 0, time.UTC), time.Hour, Hour}},
+		{"Oct 7, 1970 at 4:30pm", Range{time.Date(1970, 10, 7, 16, 30, 0, 0, time.UTC), time.Minute, Minute}},
+
 		// RFC3339
-		{"2006-01-02T15:04:05Z", Range{time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC), time.Second}},
-		{"1990-12-31T15:59:59-08:00", Range{time.Date(1990, 12, 31, 15, 59, 59, 0, time.FixedZone("", -8*60*60)), time.Second}},
+		{"2006-01-02T15:04:05Z", Range{time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC), time.Second, Second}},
+		{"1990-12-31T15:59:59-08:00", Range{time.Date(1990, 12, 31, 15, 59, 59, 0, time.FixedZone("", -8*60*60)), time.Second, Second}},
 
 		// from A to B
 		{
@@ -489,6 +522,12 @@ var rangeStrs = []string{
 	"31-3-2014 UTC-8",
 	"31/3/2014",
 	"31-3-2014",
+	`30 seconds ago`,
+	`12 minutes ago`,
+	`3 hours from now`,
+	`in an hour`,
+	`in two weeks`,
+	`within 3 days`,
 	`One day ago`,
 	`1 day ago`,
 	`3 days ago`,
@@ -522,6 +561,15 @@ var rangeStrs = []string{
 	`1999 AD`,
 	`2008CE`,
 	`2008 CE`,
+	"Friday",
+	"last Monday",
+	"next Wed",
+	"5pm",
+	"noon",
+	"10:30am",
+	"17:45:10",
+	"Tomorrow at 5pm",
+	"5pm tomorrow",
 	"2006-01-02T15:04:05Z",
 	"1990-12-31T15:59:59-08:00",
 	"From 3 feb 2022 to 6 oct 2022",
@@ -577,3 +625,15 @@ func FuzzReplaceAllRangesByFunc_allRanges(f *testing.F) {
 		}
 	})
 }
+
+func TestReplaceAllRangesByFunc_alert(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	input := "Disk alert fired 12 minutes ago and again 30 seconds ago."
+	want := "Disk alert fired [02:36:00-02:37:00] and again [02:48:03-02:48:04]."
+	got := ReplaceAllRangesByFunc(input, now, Past, func(src string, r Range) string {
+		return fmt.Sprintf("[%s-%s]", r.Start().Format("15:04:05"), r.End().Format("15:04:05"))
+	})
+	if got != want {
+		t.Errorf("\ngot  %q\nwant %q", got, want)
+	}
+}
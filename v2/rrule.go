@@ -0,0 +1,402 @@
+package anytime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// weekdayCodes are the two-letter names RFC 5545 uses for days of the week,
+// indexed by time.Weekday.
+var weekdayCodes = [7]string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// RRULE returns rec as the value of an RFC 5545 RRULE property, such as
+// "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=0;BYSECOND=0" for
+// "every weekday at 9am". The start of rec, and with it the time zone of the
+// time of day, belongs in the DTSTART property given by the DTSTART method.
+// Weekly rules with an interval say which day weeks start on, since RFC 5545
+// would otherwise start them on Monday, and that is the only case in which
+// it matters.
+func (rec Recurrence) RRULE() string {
+	parts := []string{"FREQ=" + strings.ToUpper(rec.frequency.String())}
+	if rec.interval > 1 {
+		parts = append(parts, fmt.Sprintf("INTERVAL=%d", rec.interval))
+		if rec.frequency == Weekly {
+			parts = append(parts, "WKST="+weekdayCodes[rec.weekStart])
+		}
+	}
+	switch rec.frequency {
+	case Weekly:
+		parts = append(parts, "BYDAY="+rec.byDay(""))
+	case Monthly:
+		switch {
+		case rec.nth != 0 && rec.numWeekdays() == 1:
+			parts = append(parts, "BYDAY="+rec.byDay(strconv.Itoa(rec.nth)))
+		case rec.nth != 0:
+			// The nth of the days on any of the weekdays, as in "every first
+			// weekday of the month", rather than the nth of each weekday.
+			parts = append(parts, "BYDAY="+rec.byDay(""), fmt.Sprintf("BYSETPOS=%d", rec.nth))
+		default:
+			parts = append(parts, fmt.Sprintf("BYMONTHDAY=%d", rec.monthDay))
+		}
+	case Yearly:
+		parts = append(parts, fmt.Sprintf("BYMONTH=%d", rec.month), fmt.Sprintf("BYMONTHDAY=%d", rec.monthDay))
+	}
+	if c := rec.clock; c != nil {
+		parts = append(parts, fmt.Sprintf("BYHOUR=%d;BYMINUTE=%d;BYSECOND=%d", c.hour, c.minute, c.second))
+	}
+	return strings.Join(parts, ";")
+}
+
+// DTSTART returns the RFC 5545 DTSTART property that goes with the RRULE of
+// rec, such as "DTSTART;TZID=America/New_York:20220929T090000". Starts in
+// UTC are written as such, and starts in zones with a fixed whole number of
+// hours from UTC use the matching Etc/GMT zone, so that "every day at noon
+// UTC+2" keeps its zone. Starts in any other zone without an IANA name,
+// such as time.Local, are written as floating times that take the zone of
+// whoever reads them.
+func (rec Recurrence) DTSTART() string {
+	t := rec.Start()
+	const layout = "20060102T150405"
+	loc := t.Location()
+	if loc == time.UTC {
+		return "DTSTART:" + t.Format(layout) + "Z"
+	}
+	if name := loc.String(); name != "Local" {
+		if _, err := time.LoadLocation(name); err == nil {
+			return "DTSTART;TZID=" + name + ":" + t.Format(layout)
+		}
+	}
+	if _, offset := t.Zone(); offset%3600 == 0 && offset != 0 {
+		// The signs of Etc/GMT zones are the opposite of the usual ones.
+		return fmt.Sprintf("DTSTART;TZID=Etc/GMT%+d:%s", -offset/3600, t.Format(layout))
+	}
+	return "DTSTART:" + t.Format(layout)
+}
+
+// numWeekdays returns how many days of the week rec has.
+func (rec Recurrence) numWeekdays() int {
+	n := 0
+	for _, ok := range rec.weekdays {
+		if ok {
+			n++
+		}
+	}
+	return n
+}
+
+// byDay returns the weekdays of rec as a BYDAY list, each prefixed with n.
+func (rec Recurrence) byDay(n string) string {
+	var days []string
+	for wd, ok := range rec.weekdays {
+		if ok {
+			days = append(days, n+weekdayCodes[wd])
+		}
+	}
+	return strings.Join(days, ",")
+}
+
+// ParseRRULE parses the value of an RFC 5545 RRULE property, optionally
+// prefixed by "RRULE:", into a Recurrence counting from the day of start, as
+// given by DTSTART. The rules it accepts are those that a Recurrence can
+// express: daily, weekly, monthly or yearly ones with an interval, days of
+// the week, a day or weekday of the month, a month and a single time of day.
+// The nth of several days of the week in a month is given by BYSETPOS, as in
+// "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=1".
+// Without BYHOUR, the occurrences last the whole day. Weeks start on the day
+// given by WKST, or on Monday if there is none, as RFC 5545 says. Rules with
+// parts that a Recurrence cannot express with their frequency, such as
+// BYMONTH in a monthly rule, are rejected rather than losing those parts.
+func ParseRRULE(s string, start time.Time) (Recurrence, error) {
+	rec := Recurrence{start: truncateDay(start).start, interval: 1, weekStart: time.Monday}
+	s = strings.TrimPrefix(strings.TrimSpace(s), "RRULE:")
+	var c clock
+	hasFreq, hasClock := false, false
+	setPos := 0
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			return Recurrence{}, fmt.Errorf("RRULE part %q is not of the form KEY=VALUE", part)
+		}
+		k = strings.ToUpper(k)
+		if seen[k] {
+			return Recurrence{}, fmt.Errorf("RRULE %q has more than one %s", s, k)
+		}
+		seen[k] = true
+		var err error
+		switch k {
+		case "FREQ":
+			f, ok := map[string]Frequency{"DAILY": Daily, "WEEKLY": Weekly, "MONTHLY": Monthly, "YEARLY": Yearly}[strings.ToUpper(v)]
+			if !ok {
+				return Recurrence{}, fmt.Errorf("unsupported RRULE frequency %q", v)
+			}
+			rec.frequency = f
+			hasFreq = true
+		case "INTERVAL":
+			rec.interval, err = parseRuleInt(k, v, 1, maxInterval)
+		case "BYDAY":
+			err = rec.parseByDay(v)
+		case "BYSETPOS":
+			setPos, err = parseRuleInt(k, v, -1, 5)
+			if err == nil && setPos == 0 {
+				err = fmt.Errorf("RRULE BYSETPOS must not be 0")
+			}
+		case "BYMONTHDAY":
+			rec.monthDay, err = parseRuleInt(k, v, -1, 31)
+			if err == nil && rec.monthDay == 0 {
+				err = fmt.Errorf("RRULE BYMONTHDAY must not be 0")
+			}
+		case "BYMONTH":
+			var m int
+			m, err = parseRuleInt(k, v, 1, 12)
+			rec.month = time.Month(m)
+		case "BYHOUR":
+			c.hour, err = parseRuleInt(k, v, 0, 23)
+			hasClock = true
+		case "BYMINUTE":
+			c.minute, err = parseRuleInt(k, v, 0, 59)
+		case "BYSECOND":
+			c.second, err = parseRuleInt(k, v, 0, 59)
+		case "WKST":
+			wd, ok := weekdayCodeToWeekday(v)
+			if !ok {
+				err = fmt.Errorf("bad RRULE WKST %q", v)
+			}
+			rec.weekStart = wd
+		default:
+			return Recurrence{}, fmt.Errorf("unsupported RRULE part %q", part)
+		}
+		if err != nil {
+			return Recurrence{}, err
+		}
+	}
+	if !hasFreq {
+		return Recurrence{}, fmt.Errorf("RRULE %q has no FREQ", s)
+	}
+	if setPos != 0 {
+		if rec.frequency != Monthly || rec.nth != 0 || rec.weekdays == [7]bool{} {
+			return Recurrence{}, fmt.Errorf("RRULE %q has a BYSETPOS without unnumbered BYDAY in a monthly rule", s)
+		}
+		rec.nth = setPos
+	}
+	if rec.nth != 0 && rec.frequency != Monthly {
+		return Recurrence{}, fmt.Errorf("RRULE %q has a numbered BYDAY outside a monthly rule", s)
+	}
+	if rec.weekdays != [7]bool{} && rec.nth == 0 && rec.frequency != Weekly {
+		return Recurrence{}, fmt.Errorf("RRULE %q has an unnumbered BYDAY outside a weekly rule", s)
+	}
+	if seen["BYMONTH"] && rec.frequency != Yearly {
+		return Recurrence{}, fmt.Errorf("RRULE %q has a BYMONTH outside a yearly rule", s)
+	}
+	if seen["BYMONTHDAY"] && rec.frequency != Monthly && rec.frequency != Yearly {
+		return Recurrence{}, fmt.Errorf("RRULE %q has a BYMONTHDAY outside a monthly or yearly rule", s)
+	}
+	if seen["BYMONTHDAY"] && rec.nth != 0 {
+		return Recurrence{}, fmt.Errorf("RRULE %q has both BYMONTHDAY and a numbered BYDAY", s)
+	}
+	if (seen["BYMINUTE"] || seen["BYSECOND"]) && !hasClock {
+		return Recurrence{}, fmt.Errorf("RRULE %q has BYMINUTE or BYSECOND without BYHOUR", s)
+	}
+	if rec.frequency == Yearly && (rec.month == 0) != (rec.monthDay == 0) {
+		return Recurrence{}, fmt.Errorf("RRULE %q needs both BYMONTH and BYMONTHDAY", s)
+	}
+	if rec.frequency == Yearly && !possibleMonthDay(rec.month, rec.monthDay) {
+		return Recurrence{}, fmt.Errorf("RRULE %q names a day that %v never has", s, rec.month)
+	}
+	rec.fillDefaults()
+	if hasClock {
+		c.granularity = clockGranularity(c.minute, c.second)
+		rec.clock = &c
+	}
+	return rec, nil
+}
+
+// parseByDay sets the weekdays of rec from an RRULE BYDAY list such as
+// "MO,WE" or "2TU". A numbered day must be the only one, and its number
+// becomes rec.nth, since a Recurrence picks one day a month.
+func (rec *Recurrence) parseByDay(v string) error {
+	for _, d := range strings.Split(v, ",") {
+		d = strings.ToUpper(d)
+		if len(d) < 2 {
+			return fmt.Errorf("bad RRULE BYDAY entry %q", d)
+		}
+		code, num := d[len(d)-2:], d[:len(d)-2]
+		wd, ok := weekdayCodeToWeekday(code)
+		if !ok {
+			return fmt.Errorf("bad RRULE BYDAY entry %q", d)
+		}
+		nth := 0
+		if num != "" {
+			n, err := strconv.Atoi(num)
+			if err != nil || n == 0 || n < -1 || n > 5 {
+				return fmt.Errorf("unsupported RRULE BYDAY entry %q", d)
+			}
+			nth = n
+		}
+		if rec.weekdays != [7]bool{} && (nth != 0 || rec.nth != 0) {
+			return fmt.Errorf("RRULE BYDAY entries %q are numbered and not alone", v)
+		}
+		rec.nth = nth
+		rec.weekdays[wd] = true
+	}
+	return nil
+}
+
+// maxInterval is the largest INTERVAL accepted by ParseRRULE.
+const maxInterval = 1000
+
+// possibleMonthDay reports whether the month m ever has the day dom, which
+// may be -1 for the last day.
+func possibleMonthDay(m time.Month, dom int) bool {
+	// 2000 was a leap year.
+	return dom <= time.Date(2000, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// weekdayCodeToWeekday returns the day of the week named by code, one of
+// weekdayCodes in any case.
+func weekdayCodeToWeekday(code string) (time.Weekday, bool) {
+	for wd, c := range weekdayCodes {
+		if strings.EqualFold(c, code) {
+			return time.Weekday(wd), true
+		}
+	}
+	return 0, false
+}
+
+// parseRuleInt parses the value v of the RRULE or cron field k as an int from
+// lo to hi.
+func parseRuleInt(k, v string, lo, hi int) (int, error) {
+	i, err := strconv.Atoi(v)
+	if err != nil || i < lo || i > hi {
+		return 0, fmt.Errorf("%s value %q is not a number from %d to %d", k, v, lo, hi)
+	}
+	return i, nil
+}
+
+// clockGranularity returns how precisely a time of day with the given minute
+// and second was given, assuming zeroes were left unsaid.
+func clockGranularity(minute, second int) Granularity {
+	switch {
+	case second != 0:
+		return Second
+	case minute != 0:
+		return Minute
+	}
+	return Hour
+}
+
+// Cron returns rec as a standard 5-field cron expression giving the start
+// times of its occurrences, such as "0 9 * * 1,2,3,4,5" for
+// "every weekday at 9am". Occurrences lasting the whole day start at
+// midnight. It returns false if rec cannot be expressed in cron, as for
+// intervals other than one, weekdays or last days of the month, and times
+// of day with seconds. The time zone of the time of day is not included.
+func (rec Recurrence) Cron() (string, bool) {
+	if rec.interval != 1 || rec.nth != 0 || rec.monthDay < 0 {
+		return "", false
+	}
+	var c clock
+	if rec.clock != nil {
+		c = *rec.clock
+	}
+	if c.second != 0 {
+		return "", false
+	}
+	dom, mon, dow := "*", "*", "*"
+	switch rec.frequency {
+	case Weekly:
+		var days []string
+		for wd, ok := range rec.weekdays {
+			if ok {
+				days = append(days, strconv.Itoa(wd))
+			}
+		}
+		dow = strings.Join(days, ",")
+	case Monthly:
+		dom = strconv.Itoa(rec.monthDay)
+	case Yearly:
+		dom = strconv.Itoa(rec.monthDay)
+		mon = strconv.Itoa(int(rec.month))
+	}
+	return fmt.Sprintf("%d %d %s %s %s", c.minute, c.hour, dom, mon, dow), true
+}
+
+// ParseCron parses a standard 5-field cron expression into a Recurrence
+// counting from the day of start. The minute and hour must be single numbers.
+// At most one of the day of the month and the day of the week may be given,
+// the day of the month as a single number, and the day of the week as a
+// list of numbers or ranges such as "1-5", with 0 or 7 for Sunday. A month
+// needs a day of the month.
+func ParseCron(s string, start time.Time) (Recurrence, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 5 {
+		return Recurrence{}, fmt.Errorf("cron expression %q does not have 5 fields", s)
+	}
+	minute, err := parseRuleInt("cron minute", fields[0], 0, 59)
+	if err != nil {
+		return Recurrence{}, err
+	}
+	hour, err := parseRuleInt("cron hour", fields[1], 0, 23)
+	if err != nil {
+		return Recurrence{}, err
+	}
+	rec := Recurrence{
+		start:    truncateDay(start).start,
+		interval: 1,
+		clock:    &clock{hour: hour, minute: minute, granularity: clockGranularity(minute, 0)},
+	}
+	dom, mon, dow := fields[2], fields[3], fields[4]
+	switch {
+	case dom == "*" && mon == "*" && dow == "*":
+		rec.frequency = Daily
+	case dom == "*" && mon == "*":
+		rec.frequency = Weekly
+		if err := rec.parseCronWeekdays(dow); err != nil {
+			return Recurrence{}, err
+		}
+	case dow == "*":
+		rec.frequency = Monthly
+		if rec.monthDay, err = parseRuleInt("cron day of month", dom, 1, 31); err != nil {
+			return Recurrence{}, err
+		}
+		if mon != "*" {
+			rec.frequency = Yearly
+			m, err := parseRuleInt("cron month", mon, 1, 12)
+			if err != nil {
+				return Recurrence{}, err
+			}
+			rec.month = time.Month(m)
+			if !possibleMonthDay(rec.month, rec.monthDay) {
+				return Recurrence{}, fmt.Errorf("cron expression %q names a day that %v never has", s, rec.month)
+			}
+		}
+	default:
+		return Recurrence{}, fmt.Errorf("unsupported cron expression %q", s)
+	}
+	return rec, nil
+}
+
+// parseCronWeekdays sets the weekdays of rec from a cron day-of-week field
+// such as "1,3" or "1-5".
+func (rec *Recurrence) parseCronWeekdays(v string) error {
+	for _, item := range strings.Split(v, ",") {
+		lo, hi, isRange := strings.Cut(item, "-")
+		if !isRange {
+			hi = lo
+		}
+		from, err := parseRuleInt("cron day of week", lo, 0, 7)
+		if err != nil {
+			return err
+		}
+		to, err := parseRuleInt("cron day of week", hi, from, 7)
+		if err != nil {
+			return err
+		}
+		for d := from; d <= to; d++ {
+			rec.weekdays[d%7] = true
+		}
+	}
+	return nil
+}
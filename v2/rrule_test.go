@@ -0,0 +1,275 @@
+package anytime
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestRecurrence_RRULEAndCron(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	tests := []struct {
+		input     string
+		wantRRULE string
+		wantCron  string
+	}{
+		{"every weekday at 9am", "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=0;BYSECOND=0", "0 9 * * 1,2,3,4,5"},
+		{"every monday and wednesday", "FREQ=WEEKLY;BYDAY=MO,WE", "0 0 * * 1,3"},
+		{"every other week", "FREQ=WEEKLY;INTERVAL=2;WKST=SU;BYDAY=TH", ""},
+		{"every 2nd tuesday of the month", "FREQ=MONTHLY;BYDAY=2TU", ""},
+		{"every 2nd tuesday", "FREQ=MONTHLY;BYDAY=2TU", ""},
+		{"every first weekday of the month", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=1", ""},
+		{"every last weekday of the month", "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", ""},
+		{"every last friday of the month at 5pm", "FREQ=MONTHLY;BYDAY=-1FR;BYHOUR=17;BYMINUTE=0;BYSECOND=0", ""},
+		{"every first of the month at 8:30", "FREQ=MONTHLY;BYMONTHDAY=1;BYHOUR=8;BYMINUTE=30;BYSECOND=0", "30 8 1 * *"},
+		{"every last day of the month", "FREQ=MONTHLY;BYMONTHDAY=-1", ""},
+		{"every march 3", "FREQ=YEARLY;BYMONTH=3;BYMONTHDAY=3", "0 0 3 3 *"},
+		{"every day at 12:00:30", "FREQ=DAILY;BYHOUR=12;BYMINUTE=0;BYSECOND=30", ""},
+		{"every day", "FREQ=DAILY", "0 0 * * *"},
+		{"every 3 days", "FREQ=DAILY;INTERVAL=3", ""},
+		{"every 2 months", "FREQ=MONTHLY;INTERVAL=2;BYMONTHDAY=29", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			rec, _, err := ParseRecurrence(tt.input, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			rrule := rec.RRULE()
+			if rrule != tt.wantRRULE {
+				t.Errorf("RRULE() = %q, want %q", rrule, tt.wantRRULE)
+			}
+			back, err := ParseRRULE("RRULE:"+rrule, now)
+			if err != nil {
+				t.Fatalf("ParseRRULE(%q) error = %v", rrule, err)
+			}
+			if !reflect.DeepEqual(back, rec) {
+				t.Errorf("ParseRRULE(%q) = %+v, want %+v", rrule, back, rec)
+			}
+
+			cron, ok := rec.Cron()
+			if ok != (tt.wantCron != "") || cron != tt.wantCron {
+				t.Errorf("Cron() = %q, %v, want %q", cron, ok, tt.wantCron)
+			}
+			if !ok {
+				return
+			}
+			back, err = ParseCron(cron, now)
+			if err != nil {
+				t.Fatalf("ParseCron(%q) error = %v", cron, err)
+			}
+			// Occurrences parsed from cron are instants rather than whole
+			// days, so only compare when they start.
+			after, backAfter := now, now
+			for i := 0; i < 5; i++ {
+				o, ok := rec.Next(after)
+				bo, bok := back.Next(backAfter)
+				if !ok || !bok || !o.Start().Equal(bo.Start()) {
+					t.Fatalf("occurrence %d starts at %v from cron, want %v", i, bo.Start(), o.Start())
+				}
+				after, backAfter = o.Start(), bo.Start()
+			}
+		})
+	}
+}
+
+func TestParseRRULE_fail(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"BYDAY=MO",
+		"FREQ=HOURLY",
+		"FREQ=WEEKLY;BYDAY=XX",
+		"FREQ=WEEKLY;COUNT=3",
+		"FREQ=WEEKLY;INTERVAL=0",
+		"FREQ=WEEKLY;BYDAY=2TU",
+		"FREQ=WEEKLY;WKST=XX",
+		"FREQ=MONTHLY;BYDAY=TU",
+		"FREQ=MONTHLY;BYDAY=1MO,2TU",
+		"FREQ=MONTHLY;BYDAY=1MO,1TU",
+		"FREQ=MONTHLY;BYDAY=2MO;BYSETPOS=1",
+		"FREQ=MONTHLY;BYMONTHDAY=1;BYSETPOS=1",
+		"FREQ=WEEKLY;BYDAY=MO;BYSETPOS=1",
+		"FREQ=MONTHLY;BYDAY=MO;BYSETPOS=0",
+		"FREQ=MONTHLY;BYMONTHDAY=0",
+		"FREQ=YEARLY;BYMONTH=3",
+		"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+		"FREQ=YEARLY;BYMONTH=4;BYMONTHDAY=31",
+		"FREQ=YEARLY;INTERVAL=65536;BYMONTH=2;BYMONTHDAY=29",
+		"FREQ=DAILY;BYHOUR=24",
+		"FREQ=DAILY;BYMINUTE=30",
+		"FREQ=DAILY;BYMONTHDAY=1",
+		"FREQ=DAILY;BYMONTH=3",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=WEEKLY;BYDAY=MO;BYMONTH=3",
+		"FREQ=MONTHLY;BYMONTH=3;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYDAY=2TU;BYMONTHDAY=1",
+		"FREQ=YEARLY;BYMONTH=3;BYMONTHDAY=1;BYDAY=MO",
+		"FREQ=DAILY;FREQ=WEEKLY",
+		"FREQ",
+	} {
+		t.Run(s, func(t *testing.T) {
+			if _, err := ParseRRULE(s, time.Time{}); err == nil {
+				t.Error("parsing succeeded, want failure")
+			}
+		})
+	}
+}
+
+func TestParseCron(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	tests := []struct {
+		cron      string
+		wantRRULE string
+	}{
+		{"0 9 * * 1-5", "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR;BYHOUR=9;BYMINUTE=0;BYSECOND=0"},
+		{"15 18 * * 0,6", "FREQ=WEEKLY;BYDAY=SU,SA;BYHOUR=18;BYMINUTE=15;BYSECOND=0"},
+		{"0 0 * * 7", "FREQ=WEEKLY;BYDAY=SU;BYHOUR=0;BYMINUTE=0;BYSECOND=0"},
+		{"30 6 * * *", "FREQ=DAILY;BYHOUR=6;BYMINUTE=30;BYSECOND=0"},
+		{"0 12 15 * *", "FREQ=MONTHLY;BYMONTHDAY=15;BYHOUR=12;BYMINUTE=0;BYSECOND=0"},
+		{"0 12 25 12 *", "FREQ=YEARLY;BYMONTH=12;BYMONTHDAY=25;BYHOUR=12;BYMINUTE=0;BYSECOND=0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.cron, func(t *testing.T) {
+			rec, err := ParseCron(tt.cron, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := rec.RRULE(); got != tt.wantRRULE {
+				t.Errorf("RRULE() = %q, want %q", got, tt.wantRRULE)
+			}
+		})
+	}
+}
+
+func TestParseCron_fail(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"0 9 * *",
+		"*/5 * * * *",
+		"0 9 1 * 1",
+		"60 9 * * *",
+		"0 24 * * *",
+		"0 9 * * 8",
+		"0 9 * * 5-1",
+		"0 9 32 * *",
+		"0 9 * 3 *",
+		"0 9 30 2 *",
+	} {
+		t.Run(s, func(t *testing.T) {
+			if _, err := ParseCron(s, time.Time{}); err == nil {
+				t.Error("parsing succeeded, want failure")
+			}
+		})
+	}
+}
+
+func TestRecurrence_RRULEWeekStart(t *testing.T) {
+	// Counting weeks from Monday, as RFC 5545 does by default, would put
+	// the second occurrence on October 10 rather than October 16.
+	sunday := time.Date(2022, 10, 2, 0, 0, 0, 0, time.UTC)
+	rec, _, err := ParseRecurrence("every other sunday and monday", sunday)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := rec.RRULE(), "FREQ=WEEKLY;INTERVAL=2;WKST=SU;BYDAY=SU,MO"; got != want {
+		t.Errorf("RRULE() = %q, want %q", got, want)
+	}
+	var got []int
+	for _, o := range rec.Between(RangeFromTimes(sunday, sunday.AddDate(0, 0, 16))) {
+		got = append(got, o.Start().Day())
+	}
+	if want := []int{2, 3, 16, 17}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Between() days = %v, want %v", got, want)
+	}
+	for _, rrule := range []string{"FREQ=WEEKLY;BYDAY=SU,MO;WKST=MO", "FREQ=WEEKLY;INTERVAL=2;BYDAY=SU,MO;WKST=su"} {
+		if _, err := ParseRRULE(rrule, sunday); err != nil {
+			t.Errorf("ParseRRULE(%q) error = %v", rrule, err)
+		}
+	}
+}
+
+func TestRecurrence_RRULEMondayWeekStart(t *testing.T) {
+	sunday := time.Date(2022, 10, 2, 0, 0, 0, 0, time.UTC)
+	rec, _, err := ParseRecurrence("every other sunday and monday", sunday, WeekStartsOn(time.Monday))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := rec.RRULE(), "FREQ=WEEKLY;INTERVAL=2;WKST=MO;BYDAY=SU,MO"; got != want {
+		t.Errorf("RRULE() = %q, want %q", got, want)
+	}
+
+	// Without WKST, RFC 5545 starts weeks on Monday.
+	parsed, err := ParseRRULE("FREQ=WEEKLY;INTERVAL=2;BYDAY=SU,MO", sunday)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range []Recurrence{rec, parsed} {
+		var got []int
+		for _, o := range r.Between(RangeFromTimes(sunday, sunday.AddDate(0, 0, 16))) {
+			got = append(got, o.Start().Day())
+		}
+		if want := []int{2, 10, 16}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Between() days = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestParseRRULE_bigInterval(t *testing.T) {
+	start := time.Date(2000, 3, 1, 0, 0, 0, 0, time.UTC)
+	rec, err := ParseRRULE("FREQ=YEARLY;INTERVAL=100;BYMONTH=2;BYMONTHDAY=29", start)
+	if err != nil {
+		t.Fatal(err)
+	}
+	o, ok := rec.Next(start)
+	if !ok {
+		t.Fatal("Next() found nothing")
+	}
+	if want := time.Date(2400, 2, 29, 0, 0, 0, 0, time.UTC); !o.Start().Equal(want) {
+		t.Errorf("Next() = %v, want %v", o.Start(), want)
+	}
+
+	// Every thousand years from 2022 never reaches a leap year.
+	rec, err = ParseRRULE("FREQ=YEARLY;INTERVAL=1000;BYMONTH=2;BYMONTHDAY=29", start.AddDate(22, 0, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o, ok := rec.Next(start); ok {
+		t.Errorf("Next() = %v, want nothing", o)
+	}
+}
+
+func TestRecurrence_DTSTART(t *testing.T) {
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	tests := []struct {
+		input string
+		now   time.Time
+		want  string
+	}{
+		{"every day", now, "DTSTART:20220929T000000Z"},
+		{"every weekday at 9am", now.In(ny), "DTSTART;TZID=America/New_York:20220928T090000"},
+		{"every day at noon UTC+2", now, "DTSTART;TZID=Etc/GMT-2:20220929T120000"},
+		{"every day at 5:30pm", now.In(fixedZoneHM(5, 30)), "DTSTART:20220929T173000"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			rec, _, err := ParseRecurrence(tt.input, tt.now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := rec.DTSTART(); got != tt.want {
+				t.Errorf("DTSTART() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+	rec, _, err := ParseRecurrence("every day at noon UTC+2", now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := rec.Start(), time.Date(2022, 9, 29, 10, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("Start() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,284 @@
+package anytime
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxSharedTokens limits how far parseSharedRange looks for a connector and
+// for the end of a range, so that it stays cheap when FindAll calls it at
+// every word of a long text.
+const maxSharedTokens = 8
+
+// dashes are the characters that can join the ends of a range within a
+// word, as in "3-5", "mon–fri" or "2019—2021".
+const dashes = "-–—"
+
+// token is a word of a string, or one of the parts of a word split at a dash
+// as in "9am-5pm".
+type token struct {
+	// start and end are the indices of the token in the string.
+	start, end int
+
+	// text is what to parse for the token. It is the lower-cased token,
+	// possibly with words added to it, such as "pm" after a bare hour.
+	text string
+}
+
+// tokenize splits s into at most max tokens, starting at index start. Words
+// with a single dash between other characters, such as "3-5", are split into
+// the parts on either side of the dash and the dash itself.
+func tokenize(s string, start, max int) []token {
+	var tokens []token
+	for len(tokens) < max {
+		sow, eow, w := findSignalNoise(s, start)
+		if sow == len(s) {
+			break
+		}
+		start = eow
+		i := strings.IndexAny(w, dashes)
+		if i <= 0 || strings.IndexAny(w[i+1:], dashes) >= 0 {
+			tokens = append(tokens, token{sow, eow, w})
+			continue
+		}
+		_, n := utf8.DecodeRuneInString(w[i:])
+		if i+n == len(w) {
+			tokens = append(tokens, token{sow, eow, w})
+			continue
+		}
+		tokens = append(tokens,
+			token{sow, sow + i, w[:i]},
+			token{sow + i, sow + i + n, w[i : i+n]},
+			token{sow + i + n, eow, w[i+n:]})
+	}
+	if len(tokens) > max {
+		tokens = tokens[:max]
+	}
+	return tokens
+}
+
+// joinTokens joins the texts of the tokens with spaces, returning the joined
+// text and the indices in it just past each token.
+func joinTokens(tokens []token) (string, []int) {
+	var b strings.Builder
+	ends := make([]int, len(tokens))
+	for i, t := range tokens {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(t.text)
+		ends[i] = b.Len()
+	}
+	return b.String(), ends
+}
+
+// parseSharedRange parses an explicit range at the beginning of s whose start
+// or end leaves out things that are given by the other, as in "march 3-5,
+// 2022", "may 3 to 7", "3rd to 5th of may", "march 3 to april 5, 2022",
+// "mon-fri next week", "9am-5pm tomorrow", "9 to 5 on tuesday" or
+// "2019–2021". The start may take words from the end of the range and the end
+// may take words from the start of the range, so long as that gives complete
+// implicit ranges. Bare hours, as in "9 to 5", are taken to be during the
+// working day unless "am" or "pm" says otherwise, and need a day or an "am"
+// or "pm" to be recognized. It returns false if there is no such range or if
+// its ends are complete on their own, since parseRange handles those.
+func parseSharedRange(s string, now time.Time, o opts) (r Range, parsed string, ok bool) {
+	tokens := tokenize(s, 0, 2*maxSharedTokens)
+	if len(tokens) > 0 && eq(tokens[0].text, "from") {
+		tokens = tokens[1:]
+	}
+	c := -1
+	for i, t := range tokens {
+		if i > maxSharedTokens {
+			break
+		}
+		if isConnector(t.text) {
+			c = i
+			break
+		}
+	}
+	if c < 1 || c == len(tokens)-1 {
+		return Range{}, "", false
+	}
+	if len(tokens)-c-1 > maxSharedTokens {
+		tokens = tokens[:c+1+maxSharedTokens]
+	}
+
+	// Bare numbers are first taken to be days, as in "may 3 to 7", and then
+	// hours, as in "9 to 5 on tuesday".
+	for _, hours := range []bool{false, true} {
+		start := append([]token{}, tokens[:c]...)
+		end := append([]token{}, tokens[c+1:]...)
+		inferred, needsDay := inferShared(start, end, hours)
+		if r, parsed, ok := shareRange(s, start, end, inferred, needsDay, now, o); ok {
+			return r, parsed, true
+		}
+	}
+	return Range{}, "", false
+}
+
+// shareRange is the part of parseSharedRange that parses the range with the
+// tokens start before the connector and end after it, given whether
+// inferShared changed any of them and whether the range needs a day.
+func shareRange(s string, start, end []token, inferred, needsDay bool, now time.Time, o opts) (r Range, parsed string, ok bool) {
+	// The end, taking as many words as possible from the start, as in
+	// "may 3 to 7", so long as it covers at least the first word after the
+	// connector and is as fine as the end on its own, if that can be parsed.
+	alone, _ := joinTokens(end)
+	endAlone, p, _, err := parseImplicitRange(alone, now, o)
+	endAloneOK := err == nil && len(p) == len(alone)
+	var endWords []token
+	var nEnd int
+	for j := len(start) - 1; j >= 0 && endWords == nil; j-- {
+		words := append(append([]token{}, start[:j]...), end...)
+		text, ends := joinTokens(words)
+		er, p, _, err := parseImplicitRange(text, now, o)
+		if err != nil || len(p) < ends[j] || (j > 0 && endAloneOK && er.Granularity != endAlone.Granularity) {
+			continue
+		}
+		for nEnd = 0; j+nEnd < len(words) && ends[j+nEnd] <= len(p); nEnd++ {
+		}
+		endWords = words[:j+nEnd]
+		if j > 0 {
+			inferred, needsDay = true, false
+		}
+	}
+	if endWords == nil {
+		return Range{}, "", false
+	}
+
+	// The start, taking as many words as possible from the end of the range,
+	// as in "march 3 to april 5, 2022", so long as it covers all of the start
+	// and is as fine as the start on its own, if that can be parsed, so that
+	// "today to tomorrow at 5pm" starts at the beginning of today.
+	alone, _ = joinTokens(start)
+	startAlone, p, _, err := parseImplicitRange(alone, now, o)
+	startAloneOK := err == nil && len(p) == len(alone)
+	var startRange Range
+	found := false
+	for k := 1; k <= nEnd && !found; k++ {
+		words := append(append([]token{}, start...), end[k:nEnd]...)
+		text, _ := joinTokens(words)
+		sr, p, _, err := parseImplicitRange(text, now, o)
+		if err != nil || len(p) != len(text) || (k < nEnd && startAloneOK && sr.Granularity != startAlone.Granularity) {
+			continue
+		}
+		startRange, found = sr, true
+		if k < nEnd {
+			inferred, needsDay = true, false
+		}
+	}
+	if !found || !inferred || needsDay {
+		return Range{}, "", false
+	}
+
+	text, _ := joinTokens(endWords)
+	endRange, _, err := parseRangeEnd(text, startRange, now, o)
+	if err != nil {
+		return Range{}, "", false
+	}
+	r = Range{
+		startRange.Start(),
+		endRange.Start().Sub(startRange.Start()),
+		finer(startRange.Granularity, endRange.Granularity),
+	}
+	return r, s[:end[nEnd-1].end], true
+}
+
+// inferShared fills in what is left out of years and, if hours is true, bare
+// hours at the ends of the start and end of a range, as in "2019–2021" or "9
+// to 5", by changing the texts of their tokens. It returns whether it changed
+// any, and whether the range needs to be on a given day to be taken for one,
+// as with "9 to 5", which could just as well be a range of numbers.
+func inferShared(start, end []token, hours bool) (changed, needsDay bool) {
+	s, e := &start[len(start)-1], &end[0]
+
+	// Years, as in "2019–2021"
+	if len(start) == 1 && isYear(s.text) && isYear(e.text) {
+		s.text += " ad"
+		e.text += " ad"
+		return true, false
+	}
+
+	// Hours, as in "9 to 5" or "9-11am"
+	if !hours {
+		return false, false
+	}
+	h1, m1, ok1 := hourAndMeridiem(start, len(start)-1)
+	h2, m2, ok2 := hourAndMeridiem(end, 0)
+	if !ok1 || !ok2 || (m1 != "" && m2 != "") {
+		return false, false
+	}
+	bare1, bare2 := m1 == "", m2 == ""
+	switch {
+	case m1 == "" && m2 == "":
+		m1 = "am"
+		if h1 < 7 || h1 == 12 {
+			m1 = "pm"
+		}
+		m2 = meridiemAfter(h1, m1, h2)
+		needsDay = true
+	case m1 == "":
+		m1 = m2
+		if hour24(h1, m1) >= hour24(h2, m2) {
+			m1 = "am"
+		}
+	default:
+		m2 = meridiemAfter(h1, m1, h2)
+	}
+	if bare1 {
+		s.text += m1
+	}
+	if bare2 {
+		e.text += m2
+	}
+	return true, needsDay
+}
+
+// isYear reports whether w is a four-digit year.
+func isYear(w string) bool {
+	y, err := strconv.Atoi(w)
+	return err == nil && len(w) == 4 && okYear(y)
+}
+
+// hourAndMeridiem returns the hour from 1 to 12 of a bare hour like "9" at
+// tokens[i], along with "am" or "pm" if it is followed by one, or of an hour
+// like "9am", or of a bare hour followed by "am" or "pm" ending at
+// tokens[i].
+func hourAndMeridiem(tokens []token, i int) (h int, meridiem string, ok bool) {
+	w := tokens[i].text
+	if i > 0 && (w == "am" || w == "pm") {
+		meridiem, w = w, tokens[i-1].text
+	} else if i+1 < len(tokens) && (tokens[i+1].text == "am" || tokens[i+1].text == "pm") {
+		meridiem = tokens[i+1].text
+	}
+	if sm := clock12Rx.FindStringSubmatch(w); sm != nil && sm[2] == "" {
+		w, meridiem = sm[1], sm[4]
+	}
+	if !hourRx.MatchString(w) {
+		return 0, "", false
+	}
+	h, _ = strconv.Atoi(w)
+	return h, meridiem, 1 <= h && h <= 12
+}
+
+// hour24 returns the hour on the 24-hour clock of hour h from 1 to 12 with
+// the meridiem "am" or "pm".
+func hour24(h int, meridiem string) int {
+	h %= 12
+	if meridiem == "pm" {
+		h += 12
+	}
+	return h
+}
+
+// meridiemAfter returns "am" or "pm", whichever makes the hour h2 first come
+// after the hour h1 with the meridiem m1.
+func meridiemAfter(h1 int, m1 string, h2 int) string {
+	if hour24(h2, "am") > hour24(h1, m1) {
+		return "am"
+	}
+	return "pm"
+}
@@ -0,0 +1,87 @@
+package anytime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse_sharedRanges(t *testing.T) {
+	// Thursday
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	date := func(y int, m time.Month, d, h int) time.Time {
+		return time.Date(y, m, d, h, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		input     string
+		wantStart time.Time
+		wantEnd   time.Time
+		wantGran  Granularity
+	}{
+		{"March 3-5, 2022", date(2022, 3, 3, 0), date(2022, 3, 5, 0), Day},
+		{"March 3–5, 2022", date(2022, 3, 3, 0), date(2022, 3, 5, 0), Day},
+		{"May 3 to 7", date(2023, 5, 3, 0), date(2023, 5, 7, 0), Day},
+		{"from May 3 to 7", date(2023, 5, 3, 0), date(2023, 5, 7, 0), Day},
+		{"march 3 to april 5, 2022", date(2022, 3, 3, 0), date(2022, 4, 5, 0), Day},
+		{"Mon-Fri next week", date(2022, 10, 3, 0), date(2022, 10, 7, 0), Day},
+		{"mon — fri next week", date(2022, 10, 3, 0), date(2022, 10, 7, 0), Day},
+		{"9am-5pm tomorrow", date(2022, 9, 30, 9), date(2022, 9, 30, 17), Hour},
+		{"9-11am tomorrow", date(2022, 9, 30, 9), date(2022, 9, 30, 11), Hour},
+		{"9 to 5 on tuesday", date(2022, 10, 4, 9), date(2022, 10, 4, 17), Hour},
+		{"tomorrow 9am to 5pm", date(2022, 9, 30, 9), date(2022, 9, 30, 17), Hour},
+		{"2019–2021", date(2019, 1, 1, 0), date(2021, 1, 1, 0), Year},
+		{"2019-2021", date(2019, 1, 1, 0), date(2021, 1, 1, 0), Year},
+		{"3rd to 5th of may", date(2023, 5, 3, 0), date(2023, 5, 5, 0), Day},
+		{"3rd to 5th of may 2022", date(2022, 5, 3, 0), date(2022, 5, 5, 0), Day},
+		{"from the 3rd to the 5th of may", date(2023, 5, 3, 0), date(2023, 5, 5, 0), Day},
+		{"3rd-5th of May", date(2023, 5, 3, 0), date(2023, 5, 5, 0), Day},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, parsed, err := Parse(tt.input, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Start().Equal(tt.wantStart) || !got.End().Equal(tt.wantEnd) || got.Granularity != tt.wantGran {
+				t.Errorf("got %v to %v %v, want %v to %v %v", got.Start(), got.End(), got.Granularity, tt.wantStart, tt.wantEnd, tt.wantGran)
+			}
+			if parsed != tt.input {
+				t.Errorf("parsed %q, want %q", parsed, tt.input)
+			}
+		})
+	}
+}
+
+func TestParse_sharedRangesNotTaken(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	tests := []struct {
+		input string
+		want  Range
+	}{
+		// A day doesn't take the time of the end.
+		{"today to tomorrow at 5pm", Range{time.Date(2022, 9, 29, 0, 0, 0, 0, time.UTC), 41 * time.Hour, Day}},
+		// "to" here is not a connector.
+		{"second to last friday of october", Range{time.Date(2022, 10, 21, 0, 0, 0, 0, time.UTC), 24 * time.Hour, Day}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, _, err := Parse(tt.input, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+	if _, _, err := Parse("9 to 5", now); err == nil {
+		t.Error("parsed bare hours with no day")
+	}
+}
+
+func TestFindAll_sharedRanges(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	matches := FindAll("The conference runs March 3–5, 2022 and the score was 9 to 5.", now)
+	if len(matches) != 1 || matches[0].Src != "March 3–5, 2022" {
+		t.Errorf("got %+v, want just March 3–5, 2022", matches)
+	}
+}
@@ -23,6 +23,33 @@ func fixedZone(offsetHours int) *time.Location {
 	return fixedZoneHM(offsetHours, 0)
 }
 
+// prevWeekdayFrom returns the day range of the last given weekday before the
+// day of t.
+func prevWeekdayFrom(t time.Time, day time.Weekday) Range {
+	d := t.Weekday() - day
+	if d <= 0 {
+		d += 7
+	}
+	return truncateDay(t.AddDate(0, 0, -int(d)))
+}
+
+// nextWeekdayFrom returns the day range of the next given weekday after the
+// day of t.
+func nextWeekdayFrom(t time.Time, day time.Weekday) Range {
+	d := day - t.Weekday()
+	if d <= 0 {
+		d += 7
+	}
+	return truncateDay(t.AddDate(0, 0, int(d)))
+}
+
+// thisWeekdayFrom returns the day range of the given weekday within the week
+// containing t, with weeks starting on the day first.
+func thisWeekdayFrom(t time.Time, day, first time.Weekday) Range {
+	s := truncateWeekFrom(t, first).Start()
+	return truncateDay(s.AddDate(0, 0, int((day-s.Weekday()+7)%7)))
+}
+
 // nextMonthDayTime returns the next month relative to time t, with given day of month and time of day.
 func nextMonthDayTime(t time.Time, month time.Month, day int, hour int, min int, sec int) time.Time {
 	nm := nextSpecificMonth(t, month)
@@ -49,7 +76,7 @@ func nextSpecificMonth(t time.Time, month time.Month) Range {
 	d := time.Date(y, month, 1, 0, 0, 0, 0, t.Location())
 	e := d.AddDate(0, 1, 0)
 	dur := e.Sub(d)
-	return Range{d, dur}
+	return Range{d, dur, Month}
 }
 
 // lastSpecificMonth returns the next month relative to time t.
@@ -61,7 +88,27 @@ func lastSpecificMonth(t time.Time, month time.Month) Range {
 	d := time.Date(y, month, 1, 0, 0, 0, 0, t.Location())
 	e := d.AddDate(0, 1, 0)
 	dur := e.Sub(d)
-	return Range{d, dur}
+	return Range{d, dur, Month}
+}
+
+// truncateSecond returns a time truncated to the second. It works on the
+// instant rather than the wall clock so that times in the hour repeated when
+// daylight saving time ends stay in the right occurrence of that hour.
+func truncateSecond(t time.Time) Range {
+	return Range{t.Truncate(time.Second), time.Second, Second}
+}
+
+// truncateMinute returns a time truncated to the minute.
+func truncateMinute(t time.Time) Range {
+	return Range{t.Truncate(time.Minute), time.Minute, Minute}
+}
+
+// truncateHour returns a time truncated to the hour. The minutes and seconds
+// are subtracted rather than truncated since some zones are offset from UTC
+// by a fraction of an hour.
+func truncateHour(t time.Time) Range {
+	d := time.Duration(t.Minute())*time.Minute + time.Duration(t.Second())*time.Second + time.Duration(t.Nanosecond())
+	return Range{t.Add(-d), time.Hour, Hour}
 }
 
 // truncateDay returns a date truncated to the day.
@@ -69,17 +116,23 @@ func truncateDay(t time.Time) Range {
 	y, m, d := t.Date()
 	s := time.Date(y, m, d, 0, 0, 0, 0, t.Location())
 	e := s.AddDate(0, 0, 1)
-	return Range{s, e.Sub(s)}
+	return Range{s, e.Sub(s), Day}
 }
 
-// truncateWeek returns a date truncated to the week.
+// truncateWeek returns a date truncated to the week starting on Sunday.
 func truncateWeek(t time.Time) Range {
-	for t.Weekday() != time.Sunday {
+	return truncateWeekFrom(t, time.Sunday)
+}
+
+// truncateWeekFrom returns a date truncated to the week starting on the day
+// first.
+func truncateWeekFrom(t time.Time, first time.Weekday) Range {
+	for t.Weekday() != first {
 		t = t.AddDate(0, 0, -1)
 	}
 	s := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
 	e := s.AddDate(0, 0, 7)
-	return Range{s, e.Sub(s)}
+	return Range{s, e.Sub(s), Week}
 }
 
 // truncateMonth returns a date truncated to the month.
@@ -87,12 +140,40 @@ func truncateMonth(t time.Time) Range {
 	y, m, _ := t.Date()
 	s := time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
 	e := s.AddDate(0, 1, 0)
-	return Range{s, e.Sub(s)}
+	return Range{s, e.Sub(s), Month}
+}
+
+// truncateQuarter returns a date truncated to the calendar quarter.
+func truncateQuarter(t time.Time) Range {
+	y, m, _ := t.Date()
+	s := time.Date(y, m-(m-1)%3, 1, 0, 0, 0, 0, t.Location())
+	e := s.AddDate(0, 3, 0)
+	return Range{s, e.Sub(s), Quarter}
+}
+
+// truncateHalf returns a date truncated to the half of the calendar year.
+func truncateHalf(t time.Time) Range {
+	y, m, _ := t.Date()
+	s := time.Date(y, m-(m-1)%6, 1, 0, 0, 0, 0, t.Location())
+	e := s.AddDate(0, 6, 0)
+	return Range{s, e.Sub(s), Half}
+}
+
+// truncateFiscalYear returns a date truncated to the fiscal year starting in
+// the month first.
+func truncateFiscalYear(t time.Time, first time.Month) Range {
+	y := t.Year()
+	if t.Month() < first {
+		y--
+	}
+	s := time.Date(y, first, 1, 0, 0, 0, 0, t.Location())
+	e := s.AddDate(1, 0, 0)
+	return Range{s, e.Sub(s), Year}
 }
 
 // truncateYear returns a date truncated to the year.
 func truncateYear(t time.Time) Range {
 	s := time.Date(t.Year(), 1, 1, 0, 0, 0, 0, t.Location())
 	e := s.AddDate(1, 0, 0)
-	return Range{s, e.Sub(s)}
+	return Range{s, e.Sub(s), Year}
 }
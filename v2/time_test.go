@@ -45,3 +45,67 @@ func Test_truncateWeek(t *testing.T) {
 		})
 	}
 }
+
+func Test_weekdayFrom(t *testing.T) {
+	// Thursday
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	tests := []struct {
+		day      time.Weekday
+		wantPrev time.Time
+		wantThis time.Time
+		wantNext time.Time
+	}{
+		{time.Sunday, time.Date(2022, 9, 25, 0, 0, 0, 0, time.UTC), time.Date(2022, 9, 25, 0, 0, 0, 0, time.UTC), time.Date(2022, 10, 2, 0, 0, 0, 0, time.UTC)},
+		{time.Wednesday, time.Date(2022, 9, 28, 0, 0, 0, 0, time.UTC), time.Date(2022, 9, 28, 0, 0, 0, 0, time.UTC), time.Date(2022, 10, 5, 0, 0, 0, 0, time.UTC)},
+		{time.Thursday, time.Date(2022, 9, 22, 0, 0, 0, 0, time.UTC), time.Date(2022, 9, 29, 0, 0, 0, 0, time.UTC), time.Date(2022, 10, 6, 0, 0, 0, 0, time.UTC)},
+		{time.Friday, time.Date(2022, 9, 23, 0, 0, 0, 0, time.UTC), time.Date(2022, 9, 30, 0, 0, 0, 0, time.UTC), time.Date(2022, 9, 30, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.day.String(), func(t *testing.T) {
+			if got := prevWeekdayFrom(now, tt.day); !got.Equal(truncateDay(tt.wantPrev)) {
+				t.Errorf("prevWeekdayFrom() = %v, want %v", got, tt.wantPrev)
+			}
+			if got := thisWeekdayFrom(now, tt.day, time.Sunday); !got.Equal(truncateDay(tt.wantThis)) {
+				t.Errorf("thisWeekdayFrom() = %v, want %v", got, tt.wantThis)
+			}
+			if got := nextWeekdayFrom(now, tt.day); !got.Equal(truncateDay(tt.wantNext)) {
+				t.Errorf("nextWeekdayFrom() = %v, want %v", got, tt.wantNext)
+			}
+		})
+	}
+}
+
+func Test_truncateSubDay(t *testing.T) {
+	loc := time.FixedZone("+05:30", 5*60*60+30*60)
+	tm := time.Date(2022, 10, 2, 23, 59, 58, 999999, loc)
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 01:30:10 EST comes an hour after 01:30:10 EDT, when daylight saving
+	// time ends.
+	edt := time.Date(2022, 11, 6, 1, 30, 10, 500, ny)
+	est := edt.Add(time.Hour)
+	tests := []struct {
+		name string
+		got  Range
+		want Range
+	}{
+		{"second", truncateSecond(tm), Range{time.Date(2022, 10, 2, 23, 59, 58, 0, loc), time.Second, Second}},
+		{"minute", truncateMinute(tm), Range{time.Date(2022, 10, 2, 23, 59, 0, 0, loc), time.Minute, Minute}},
+		{"hour", truncateHour(tm), Range{time.Date(2022, 10, 2, 23, 0, 0, 0, loc), time.Hour, Hour}},
+		{"second EDT", truncateSecond(edt), Range{edt.Add(-500), time.Second, Second}},
+		{"minute EDT", truncateMinute(edt), Range{edt.Add(-10*time.Second - 500), time.Minute, Minute}},
+		{"hour EDT", truncateHour(edt), Range{edt.Add(-30*time.Minute - 10*time.Second - 500), time.Hour, Hour}},
+		{"second EST", truncateSecond(est), Range{est.Add(-500), time.Second, Second}},
+		{"minute EST", truncateMinute(est), Range{est.Add(-10*time.Second - 500), time.Minute, Minute}},
+		{"hour EST", truncateHour(est), Range{est.Add(-30*time.Minute - 10*time.Second - 500), time.Hour, Hour}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !reflect.DeepEqual(tt.got, tt.want) {
+				t.Errorf("got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+}
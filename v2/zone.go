@@ -0,0 +1,259 @@
+package anytime
+
+import (
+	"errors"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrAmbiguousZone is returned when a time zone abbreviation such as "CST"
+// could stand for more than one zone and the ZonePolicy in use declines to
+// pick one.
+var ErrAmbiguousZone = errors.New("ambiguous time zone abbreviation")
+
+// errNoZoneFound is returned by parseZone when there is no time zone at all.
+var errNoZoneFound = errors.New("no time zone found")
+
+// Zone is one of the time zones that an abbreviation may stand for.
+type Zone struct {
+	// Name is the full name of the zone, as in "China Standard Time".
+	Name string
+
+	// Location is the zone as a fixed offset from UTC, named by the
+	// abbreviation.
+	Location *time.Location
+}
+
+// ZonePolicy picks the time zone meant by an abbreviation such as "CST" or
+// "IST" that is used for more than one zone. It is given the abbreviation in
+// upper case and the zones it may stand for, most widely used first, and
+// returns the chosen one or false if the abbreviation should be rejected.
+type ZonePolicy func(abbrev string, zones []Zone) (*time.Location, bool)
+
+// FirstZone is a ZonePolicy that picks the most widely used zone, such as
+// North American Central Standard Time for "CST" and India Standard Time for
+// "IST".
+func FirstZone(abbrev string, zones []Zone) (*time.Location, bool) {
+	return zones[0].Location, true
+}
+
+// RejectAmbiguousZones is a ZonePolicy that rejects every ambiguous
+// abbreviation, so that parsing fails with ErrAmbiguousZone.
+func RejectAmbiguousZones(abbrev string, zones []Zone) (*time.Location, bool) {
+	return nil, false
+}
+
+// PreferZones returns a ZonePolicy that picks the first of the named zones,
+// such as "China Standard Time" or "Israel Standard Time", that the
+// abbreviation may stand for, and otherwise falls back to FirstZone.
+func PreferZones(names ...string) ZonePolicy {
+	return func(abbrev string, zones []Zone) (*time.Location, bool) {
+		for _, name := range names {
+			for _, z := range zones {
+				if strings.EqualFold(z.Name, name) {
+					return z.Location, true
+				}
+			}
+		}
+		return FirstZone(abbrev, zones)
+	}
+}
+
+// parseZone parses a time zone starting at the first word at or after index
+// start of s. It accepts "utc" and offsets like "utc+8", abbreviations like
+// "pst" or "cest", names like "pacific time" or "central european summer time",
+// and IANA location names like "America/New_York". It returns the zone and
+// the index just past it, errNoZoneFound if there is no zone there, or
+// ErrAmbiguousZone if the zone is an abbreviation rejected by policy.
+func parseZone(s string, start int, policy ZonePolicy) (*time.Location, int, error) {
+	sow, eow, w := findSignalNoise(s, start)
+	if w == "" {
+		return nil, 0, errNoZoneFound
+	}
+	if loc, ok := parseZoneWord(w); ok {
+		return loc, eow, nil
+	}
+	if m := zoneNameRx.FindStringSubmatch(s[sow:]); m != nil {
+		return zoneNameToLocation(m), sow + len(m[0]), nil
+	}
+	if zones, ok := ambiguousZones[w]; ok {
+		loc, ok := policy(strings.ToUpper(w), zones)
+		if !ok {
+			return nil, 0, ErrAmbiguousZone
+		}
+		return loc, eow, nil
+	}
+	if name := ianaRx.FindString(s[sow:]); name != "" {
+		if loc, ok := loadIANALocation(name); ok {
+			return loc, sow + len(name), nil
+		}
+	}
+	return nil, 0, errNoZoneFound
+}
+
+// parseZoneWord parses a time zone such as "utc", "utc+8" or "pst" from the
+// lower-cased word w.
+func parseZoneWord(w string) (*time.Location, bool) {
+	// UTC time zone
+	if w == "utc" {
+		return time.UTC, true
+	}
+
+	// Time zone like "utc+8"
+	if (len(w) == len("utc+1") || len(w) == len("utc+10")) && w[:3] == "utc" {
+		h, err := strconv.Atoi(w[3:])
+		if err == nil && h >= -12 && h <= 12 {
+			return fixedZone(h), true
+		}
+	}
+
+	// Unambiguous abbreviation like "pst"
+	if z, ok := zoneAbbrevs[w]; ok {
+		return z.loc(w), true
+	}
+
+	return nil, false
+}
+
+// zoneNameToLocation returns the zone for a submatch of zoneNameRx.
+func zoneNameToLocation(m []string) *time.Location {
+	region := regionZones[strings.ToLower(m[1])]
+	switch strings.ToLower(m[2]) {
+	case "standard":
+		return region.standard.loc(region.standardAbbrev)
+	case "daylight", "summer":
+		return region.daylight.loc(region.daylightAbbrev)
+	}
+	if loc, err := time.LoadLocation(region.iana); err == nil {
+		return loc
+	}
+	// The time zone database is missing, so fall back on the offset that is
+	// in use for most of the year.
+	return region.daylight.loc(region.daylightAbbrev)
+}
+
+// loadIANALocation loads the IANA location with the given name, as in
+// "America/New_York", also trying the usual capitalisation of the name in case
+// it was written in lower or upper case.
+func loadIANALocation(name string) (*time.Location, bool) {
+	if loc, err := time.LoadLocation(name); err == nil {
+		return loc, true
+	}
+	b := []byte(strings.ToLower(name))
+	for i := range b {
+		if i == 0 || strings.IndexByte("/_-", b[i-1]) >= 0 {
+			b[i] = strings.ToUpper(string(b[i]))[0]
+		}
+	}
+	if loc, err := time.LoadLocation(string(b)); err == nil {
+		return loc, true
+	}
+	return nil, false
+}
+
+// zoneOffset is an offset from UTC in hours and minutes.
+type zoneOffset struct {
+	h, m int
+}
+
+// loc returns the offset as a fixed zone named by the upper-cased abbreviation
+// abbrev.
+func (z zoneOffset) loc(abbrev string) *time.Location {
+	m := z.m
+	if z.h < 0 {
+		m = -m
+	}
+	return time.FixedZone(strings.ToUpper(abbrev), z.h*60*60+m*60)
+}
+
+// zoneAbbrevs maps the lower-cased abbreviations of time zones to their
+// offsets from UTC. Abbreviations for daylight saving time, such as "pdt", have
+// the offset in use during daylight saving time, so "5pm pst" is always 17:00
+// at UTC-8, even in summer. Abbreviations that are also common words, such as
+// "wet" and "west", are left out.
+var zoneAbbrevs = map[string]zoneOffset{
+	"gmt":  {0, 0},
+	"est":  {-5, 0},
+	"edt":  {-4, 0},
+	"cdt":  {-5, 0},
+	"mst":  {-7, 0},
+	"mdt":  {-6, 0},
+	"pst":  {-8, 0},
+	"pdt":  {-7, 0},
+	"akst": {-9, 0},
+	"akdt": {-8, 0},
+	"hst":  {-10, 0},
+	"adt":  {-3, 0},
+	"nst":  {-3, 30},
+	"ndt":  {-2, 30},
+	"cet":  {1, 0},
+	"cest": {2, 0},
+	"eet":  {2, 0},
+	"eest": {3, 0},
+	"msk":  {3, 0},
+	"sast": {2, 0},
+	"pkt":  {5, 0},
+	"hkt":  {8, 0},
+	"sgt":  {8, 0},
+	"awst": {8, 0},
+	"jst":  {9, 0},
+	"kst":  {9, 0},
+	"acst": {9, 30},
+	"acdt": {10, 30},
+	"aest": {10, 0},
+	"aedt": {11, 0},
+	"nzst": {12, 0},
+	"nzdt": {13, 0},
+}
+
+// ambiguousZones maps the lower-cased abbreviations used for more than one
+// time zone to those zones, most widely used first.
+var ambiguousZones = map[string][]Zone{
+	"cst": {
+		{"Central Standard Time", zoneOffset{-6, 0}.loc("cst")},
+		{"China Standard Time", zoneOffset{8, 0}.loc("cst")},
+		{"Cuba Standard Time", zoneOffset{-5, 0}.loc("cst")},
+	},
+	"ist": {
+		{"India Standard Time", zoneOffset{5, 30}.loc("ist")},
+		{"Israel Standard Time", zoneOffset{2, 0}.loc("ist")},
+		{"Irish Standard Time", zoneOffset{1, 0}.loc("ist")},
+	},
+	"bst": {
+		{"British Summer Time", zoneOffset{1, 0}.loc("bst")},
+		{"Bangladesh Standard Time", zoneOffset{6, 0}.loc("bst")},
+	},
+	"ast": {
+		{"Atlantic Standard Time", zoneOffset{-4, 0}.loc("ast")},
+		{"Arabia Standard Time", zoneOffset{3, 0}.loc("ast")},
+	},
+}
+
+// regionZone describes the time zones of a region that has daylight saving
+// time, such as the Pacific time zone of North America.
+type regionZone struct {
+	iana                           string
+	standardAbbrev, daylightAbbrev string
+	standard, daylight             zoneOffset
+}
+
+// regionZones maps the lower-cased names of regions to their zones, for names
+// like "pacific time" and "pacific standard time".
+var regionZones = map[string]regionZone{
+	"eastern":          {"America/New_York", "est", "edt", zoneOffset{-5, 0}, zoneOffset{-4, 0}},
+	"central":          {"America/Chicago", "cst", "cdt", zoneOffset{-6, 0}, zoneOffset{-5, 0}},
+	"mountain":         {"America/Denver", "mst", "mdt", zoneOffset{-7, 0}, zoneOffset{-6, 0}},
+	"pacific":          {"America/Los_Angeles", "pst", "pdt", zoneOffset{-8, 0}, zoneOffset{-7, 0}},
+	"alaska":           {"America/Anchorage", "akst", "akdt", zoneOffset{-9, 0}, zoneOffset{-8, 0}},
+	"central european": {"Europe/Paris", "cet", "cest", zoneOffset{1, 0}, zoneOffset{2, 0}},
+	"eastern european": {"Europe/Athens", "eet", "eest", zoneOffset{2, 0}, zoneOffset{3, 0}},
+}
+
+var zoneNameRx = regexp.MustCompile(`^(?i)(eastern european|central european|eastern|central|mountain|pacific|alaska)\s+(?:(standard|daylight|summer)\s+)?time\b`)
+
+// ianaRx matches IANA location names such as "America/New_York" or
+// "Etc/GMT+5". They must have a slash, so that words like "local" are not
+// taken to be locations.
+var ianaRx = regexp.MustCompile(`^[A-Za-z]+(?:/[A-Za-z0-9_+-]+)+`)
@@ -0,0 +1,80 @@
+package anytime
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRange_zones(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	ny, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	la, err := time.LoadLocation("America/Los_Angeles")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		input      string
+		wantR      Range
+		wantParsed string
+	}{
+		{"5pm PST", Range{time.Date(2022, 9, 29, 17, 0, 0, 0, fixedZone(-8)), time.Hour, Hour}, "5pm PST"},
+		{"5pm PDT", Range{time.Date(2022, 9, 29, 17, 0, 0, 0, fixedZone(-7)), time.Hour, Hour}, "5pm PDT"},
+		{"10am CET", Range{time.Date(2022, 9, 29, 10, 0, 0, 0, fixedZone(1)), time.Hour, Hour}, "10am CET"},
+		{"10am cest tomorrow", Range{time.Date(2022, 9, 30, 10, 0, 0, 0, fixedZone(2)), time.Hour, Hour}, "10am cest tomorrow"},
+		{"9:30 IST", Range{time.Date(2022, 9, 29, 9, 30, 0, 0, fixedZoneHM(5, 30)), time.Minute, Minute}, "9:30 IST"},
+		{"9:00 America/New_York", Range{time.Date(2022, 9, 29, 9, 0, 0, 0, ny), time.Minute, Minute}, "9:00 America/New_York"},
+		{"9:00 america/new_york", Range{time.Date(2022, 9, 29, 9, 0, 0, 0, ny), time.Minute, Minute}, "9:00 america/new_york"},
+		{"noon Pacific time", Range{time.Date(2022, 9, 29, 12, 0, 0, 0, la), time.Hour, Hour}, "noon Pacific time"},
+		{"noon pacific standard time", Range{time.Date(2022, 9, 29, 12, 0, 0, 0, fixedZone(-8)), time.Hour, Hour}, "noon pacific standard time"},
+		{"march 3 2023 eastern time", truncateDay(time.Date(2023, 3, 3, 0, 0, 0, 0, ny)), "march 3 2023 eastern time"},
+		{"march 3 2023 jst", truncateDay(time.Date(2023, 3, 3, 0, 0, 0, 0, fixedZone(9))), "march 3 2023 jst"},
+		{"December 2022 Europe/Paris", truncateMonth(time.Date(2022, 12, 1, 0, 0, 0, 0, fixedZone(1))), "December 2022 Europe/Paris"},
+		{"5pm Mars/Olympus_Mons", Range{time.Date(2022, 9, 29, 17, 0, 0, 0, time.UTC), time.Hour, Hour}, "5pm"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			gotR, gotParsed, err := ParseRange(tt.input, now, Future)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !gotR.Equal(tt.wantR) {
+				t.Errorf("got range %v, want %v", gotR, tt.wantR)
+			}
+			if gotParsed != tt.wantParsed {
+				t.Errorf("parsed %q, want %q", gotParsed, tt.wantParsed)
+			}
+		})
+	}
+}
+
+func TestParseRange_zonePolicy(t *testing.T) {
+	now := time.Date(2022, 9, 29, 2, 48, 33, 123, time.UTC)
+	tests := []struct {
+		policy  ZonePolicy
+		input   string
+		want    Range
+		wantErr error
+	}{
+		{FirstZone, "5pm CST", Range{time.Date(2022, 9, 29, 17, 0, 0, 0, fixedZone(-6)), time.Hour, Hour}, nil},
+		{PreferZones("China Standard Time"), "5pm CST", Range{time.Date(2022, 9, 29, 17, 0, 0, 0, fixedZone(8)), time.Hour, Hour}, nil},
+		{PreferZones("Israel Standard Time"), "march 3 2023 ist", truncateDay(time.Date(2023, 3, 3, 0, 0, 0, 0, fixedZone(2))), nil},
+		{PreferZones("Israel Standard Time"), "5pm CST", Range{time.Date(2022, 9, 29, 17, 0, 0, 0, fixedZone(-6)), time.Hour, Hour}, nil},
+		{RejectAmbiguousZones, "5pm CST", Range{}, ErrAmbiguousZone},
+		{RejectAmbiguousZones, "march 3 2023 bst", Range{}, ErrAmbiguousZone},
+		{RejectAmbiguousZones, "5pm PST", Range{time.Date(2022, 9, 29, 17, 0, 0, 0, fixedZone(-8)), time.Hour, Hour}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, _, err := Parse(tt.input, now, WithZonePolicy(tt.policy))
+			if err != tt.wantErr {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if !got.Equal(tt.want) {
+				t.Errorf("got range %v, want %v", got, tt.want)
+			}
+		})
+	}
+}